@@ -0,0 +1,70 @@
+package pokemon
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+)
+
+// PokemonGetter gets single Pokemon by ID or name.
+type PokemonGetter interface {
+	// GetPokemon returns the Pokemon with the identifier, or model.ErrNotFound if it doesn't exist.
+	GetPokemon(ctx context.Context, idOrName string) (*model.Pokemon, error)
+}
+
+// PokemonLister lists the Pokemon.
+type PokemonLister interface {
+	// ListPokemon returns a single page of the Pokemon list, starting from page 1.
+	ListPokemon(ctx context.Context, page, pageSize int) (*Page, error)
+	// AllPokemonNames returns the name and the URL of every Pokemon.
+	AllPokemonNames(ctx context.Context) ([]model.NamedResource, error)
+}
+
+// GenerationGetter gets single Generations by ID or name.
+type GenerationGetter interface {
+	// GetGeneration returns the Generation with the identifier, or model.ErrNotFound if it doesn't exist.
+	GetGeneration(ctx context.Context, idOrName string) (*model.Generation, error)
+}
+
+// GenerationLister lists the Generations.
+type GenerationLister interface {
+	// ListGenerations returns a single page of the Generations list, starting from page 1.
+	ListGenerations(ctx context.Context, page, pageSize int) (*Page, error)
+	// AllGenerationNames returns the name and the URL of every Generation.
+	AllGenerationNames(ctx context.Context) ([]model.NamedResource, error)
+}
+
+// API describes everything the SDK can fetch. It is implemented by the Resolver, and by pokemontest.Fake for unit
+// tests. Your code can depend on it, or on the smaller interfaces it is made of, instead of on the Resolver, so it can
+// be tested without a server.
+type API interface {
+	PokemonGetter
+	PokemonLister
+	GenerationGetter
+	GenerationLister
+}
+
+var _ API = (*Resolver)(nil)
+
+// GetPokemon returns the Pokemon with the identifier (ID or name). It is the same as
+// resolver.Pokemon(idOrName).GetWithContext(ctx).
+func (r *Resolver) GetPokemon(ctx context.Context, idOrName string) (*model.Pokemon, error) {
+	return r.getPokemon(ctx, idOrName)
+}
+
+// ListPokemon returns a single page of the Pokemon list. It is the same as
+// resolver.PokemonList(page, pageSize).GetPage(ctx).
+func (r *Resolver) ListPokemon(ctx context.Context, page, pageSize int) (*Page, error) {
+	return r.PokemonList(page, pageSize).GetPage(ctx)
+}
+
+// GetGeneration returns the Generation with the identifier (ID or name). It is the same as
+// resolver.Generation(idOrName).GetWithContext(ctx).
+func (r *Resolver) GetGeneration(ctx context.Context, idOrName string) (*model.Generation, error) {
+	return r.getGeneration(ctx, idOrName)
+}
+
+// ListGenerations returns a single page of the Generations list. It is the same as
+// resolver.GenerationList(page, pageSize).GetPage(ctx).
+func (r *Resolver) ListGenerations(ctx context.Context, page, pageSize int) (*Page, error) {
+	return r.GenerationList(page, pageSize).GetPage(ctx)
+}
@@ -0,0 +1,200 @@
+package pokemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// apiDataPrefix is the path of the API in the api-data dump, which is also the prefix of the URLs in its files.
+const apiDataPrefix = "/api/v2/"
+
+// defaultListLimit is the page size the API uses when the limit query parameter is missing.
+const defaultListLimit = 20
+
+// apiDataTransport answers the requests of a Resolver from a local checkout of the PokeAPI api-data repository
+// (https://github.com/PokeAPI/api-data), instead of making them. A resource is stored in the dump by ID e.g.,
+// data/api/v2/pokemon/25/index.json, and every resource type has an index of all its resources, which is used to look
+// resources up by name and to serve list pages. The relative URLs in the dump are rewritten to absolute ones under the
+// base URL, so the responses look like the ones of the API.
+type apiDataTransport struct {
+	// the directory containing data/api/v2
+	dir     string
+	baseURL string
+
+	mu sync.Mutex
+	// the parsed index of every resource type requested so far
+	indexes map[string]*model.NamedResourceList
+}
+
+func newAPIDataTransport(dir, baseURL string) *apiDataTransport {
+	return &apiDataTransport{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		indexes: make(map[string]*model.NamedResourceList),
+	}
+}
+
+func (t *apiDataTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	rel, ok := strings.CutPrefix(req.URL.Scheme+"://"+req.URL.Host+req.URL.Path, t.baseURL+"/")
+	if !ok || req.Method != http.MethodGet {
+		return localResponse(req, http.StatusNotFound, nil), nil
+	}
+
+	resource, idOrName, _ := strings.Cut(strings.Trim(rel, "/"), "/")
+
+	var (
+		body []byte
+		err  error
+	)
+	if idOrName == "" {
+		body, err = t.list(resource, req.URL.Query().Get("limit"), req.URL.Query().Get("offset"))
+	} else {
+		body, err = t.resource(resource, idOrName)
+	}
+
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return localResponse(req, http.StatusNotFound, nil), nil
+	case err != nil:
+		return nil, err
+	}
+
+	return localResponse(req, http.StatusOK, t.rewriteURLs(body)), nil
+}
+
+// resource returns the file of a single resource, looking up its ID in the index if it is requested by name.
+func (t *apiDataTransport) resource(resource, idOrName string) ([]byte, error) {
+	id := idOrName
+	if _, err := strconv.Atoi(id); err != nil {
+		index, err := t.index(resource)
+		if err != nil {
+			return nil, err
+		}
+
+		id = ""
+		for _, res := range index.Results {
+			if res.Name == idOrName {
+				id = path.Base(strings.TrimSuffix(res.URL, "/"))
+				break
+			}
+		}
+		if id == "" {
+			return nil, model.ErrNotFound
+		}
+	}
+
+	return t.readFile(resource, id, "index.json")
+}
+
+// list returns a page of the index of the resource type, like the list endpoints of the API.
+func (t *apiDataTransport) list(resource, limitParam, offsetParam string) ([]byte, error) {
+	index, err := t.index(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := defaultListLimit, 0
+	if limitParam != "" {
+		if limit, err = strconv.Atoi(limitParam); err != nil || limit < 0 {
+			return nil, model.ErrNotFound
+		}
+	}
+	if offsetParam != "" {
+		if offset, err = strconv.Atoi(offsetParam); err != nil || offset < 0 {
+			return nil, model.ErrNotFound
+		}
+	}
+
+	page := model.NamedResourceList{
+		Count:   len(index.Results),
+		Results: index.Results[min(offset, len(index.Results)):min(offset+limit, len(index.Results))],
+	}
+	if offset+limit < len(index.Results) {
+		page.Next = fmt.Sprintf("%s%s?offset=%d&limit=%d", apiDataPrefix, resource, offset+limit, limit)
+	}
+	if offset > 0 {
+		page.Previous = fmt.Sprintf("%s%s?offset=%d&limit=%d", apiDataPrefix, resource, max(offset-limit, 0), limit)
+	}
+
+	return json.Marshal(page)
+}
+
+// index returns the index of every resource of the type, reading it from the dump the first time.
+func (t *apiDataTransport) index(resource string) (*model.NamedResourceList, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if index, ok := t.indexes[resource]; ok {
+		return index, nil
+	}
+
+	data, err := t.readFile(resource, "index.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var index model.NamedResourceList
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the %s index: %w", resource, err)
+	}
+
+	t.indexes[resource] = &index
+	return &index, nil
+}
+
+// readFile reads a file of the dump, relative to data/api/v2.
+func (t *apiDataTransport) readFile(elem ...string) ([]byte, error) {
+	for _, e := range elem {
+		// the path comes from a URL, so don't let it escape the dump
+		if e == "" || e == "." || e == ".." || strings.ContainsAny(e, `/\`) {
+			return nil, model.ErrNotFound
+		}
+	}
+
+	name := filepath.Join(append([]string{t.dir, "data", "api", "v2"}, elem...)...)
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, model.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	return data, nil
+}
+
+// rewriteURLs replaces the relative URLs of the dump e.g., "/api/v2/pokemon/25/", with URLs under the base URL.
+func (t *apiDataTransport) rewriteURLs(body []byte) []byte {
+	return bytes.ReplaceAll(body, []byte(`"`+apiDataPrefix), []byte(`"`+t.baseURL+"/"))
+}
+
+// localResponse returns a response to the request which didn't go over the network.
+func localResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,138 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"sync"
+)
+
+// BatchError describes the failure of a single item of a batch request. The errors of all failed items are combined
+// with errors.Join, so you can still use errors.Is, e.g. to check for model.ErrNotFound.
+type BatchError struct {
+	// The position of the item in the input
+	Index int
+	// The identifier of the item
+	ID string
+	// The error returned when fetching the item
+	Err error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("item %d (%s): %v", e.Index, e.ID, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// PokemonBatch is a helper type with a reference to the Resolver making the requests. It is used to fetch many Pokemon
+// concurrently.
+type PokemonBatch struct {
+	resolver *Resolver
+	ids      []string
+	workers  int
+}
+
+// WithWorkers sets the maximum number of concurrent requests for this batch, overriding Config.BatchWorkers.
+func (b *PokemonBatch) WithWorkers(workers int) *PokemonBatch {
+	b.workers = workers
+	return b
+}
+
+// Get returns the model.Pokemon for every identifier in the batch. See GetWithContext.
+func (b *PokemonBatch) Get() ([]*model.Pokemon, error) {
+	return b.GetWithContext(context.Background())
+}
+
+// GetWithContext fetches the model.Pokemon for every identifier in the batch concurrently. The results are in the same
+// order as the identifiers. If some of them fail, the result for those is nil and the returned error combines a
+// BatchError for each failed item.
+func (b *PokemonBatch) GetWithContext(ctx context.Context) ([]*model.Pokemon, error) {
+	return fetchBatch(ctx, b.ids, b.workers, b.resolver.getPokemon)
+}
+
+// GenerationBatch is a helper type with a reference to the Resolver making the requests. It is used to fetch many
+// Generations concurrently.
+type GenerationBatch struct {
+	resolver *Resolver
+	ids      []string
+	workers  int
+}
+
+// WithWorkers sets the maximum number of concurrent requests for this batch, overriding Config.BatchWorkers.
+func (b *GenerationBatch) WithWorkers(workers int) *GenerationBatch {
+	b.workers = workers
+	return b
+}
+
+// Get returns the model.Generation for every identifier in the batch. See GetWithContext.
+func (b *GenerationBatch) Get() ([]*model.Generation, error) {
+	return b.GetWithContext(context.Background())
+}
+
+// GetWithContext fetches the model.Generation for every identifier in the batch concurrently. The results are in the
+// same order as the identifiers. If some of them fail, the result for those is nil and the returned error combines a
+// BatchError for each failed item.
+func (b *GenerationBatch) GetWithContext(ctx context.Context) ([]*model.Generation, error) {
+	return fetchBatch(ctx, b.ids, b.workers, b.resolver.getGeneration)
+}
+
+// PokemonBatch returns a new PokemonBatch object with the specified identifiers (IDs or names) and a reference to the
+// Resolver.
+func (r *Resolver) PokemonBatch(ids ...string) *PokemonBatch {
+	return &PokemonBatch{
+		resolver: r,
+		ids:      ids,
+		workers:  r.batchWorkers,
+	}
+}
+
+// GenerationBatch returns a new GenerationBatch object with the specified identifiers (IDs or names) and a reference
+// to the Resolver.
+func (r *Resolver) GenerationBatch(ids ...string) *GenerationBatch {
+	return &GenerationBatch{
+		resolver: r,
+		ids:      ids,
+		workers:  r.batchWorkers,
+	}
+}
+
+// fetchBatch calls fetch for every identifier using at most the given number of workers. The results are returned in
+// input order.
+func fetchBatch[T any](ctx context.Context, ids []string, workers int, fetch func(context.Context, string) (T, error)) ([]T, error) {
+	results := make([]T, len(ids))
+	errs := make([]error, len(ids))
+
+	if workers <= 0 {
+		workers = defaultBatchWorkers
+	}
+	workers = min(workers, len(ids))
+
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				result, err := fetch(ctx, ids[i])
+				if err != nil {
+					errs[i] = &BatchError{Index: i, ID: ids[i], Err: err}
+					continue
+				}
+				results[i] = result
+			}
+		}()
+	}
+
+	for i := range ids {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
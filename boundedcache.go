@@ -0,0 +1,225 @@
+package pokemon
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// EvictionPolicy decides which entries are evicted when a BoundedCache is full.
+type EvictionPolicy int
+
+const (
+	// EvictLRU evicts the least recently used entries first.
+	EvictLRU EvictionPolicy = iota
+	// EvictLFU evicts the least frequently used entries first. Ties are broken by evicting the least recently used.
+	EvictLFU
+)
+
+// BoundedCacheOptions contains the settings of a BoundedCache.
+type BoundedCacheOptions struct {
+	// The maximum total size of the stored keys and response bodies in bytes
+	MaxBytes int64
+	// Which entries are evicted first when the cache is full. Defaults to EvictLRU
+	Policy EvictionPolicy
+}
+
+// BoundedCache is an in-memory CacheBackend with a maximum size in bytes. When storing a new entry would exceed it,
+// entries are evicted according to the EvictionPolicy, so the memory used by a long-running process stays bounded.
+type BoundedCache struct {
+	opts BoundedCacheOptions
+
+	mu      sync.Mutex
+	entries map[string]*boundedEntry
+	queue   evictionQueue
+	size    int64
+	evicted uint64
+	// a logical clock, incremented on every access, used to order entries by recency
+	clock uint64
+}
+
+type boundedEntry struct {
+	key      string
+	value    []byte
+	expires  time.Time
+	hits     uint64
+	lastUsed uint64
+	// the position of the entry in the eviction queue
+	index int
+}
+
+func (e *boundedEntry) size() int64 {
+	return int64(len(e.key) + len(e.value))
+}
+
+// NewBoundedCache creates a new BoundedCache with the specified options.
+func NewBoundedCache(opts BoundedCacheOptions) *BoundedCache {
+	return &BoundedCache{
+		opts:    opts,
+		entries: make(map[string]*boundedEntry),
+		queue:   evictionQueue{policy: opts.Policy},
+	}
+}
+
+// Get implements CacheBackend.
+func (c *BoundedCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.remove(entry)
+		return nil, ErrCacheMiss
+	}
+
+	c.clock++
+	entry.hits++
+	entry.lastUsed = c.clock
+	heap.Fix(&c.queue, entry.index)
+
+	return entry.value, nil
+}
+
+// Set implements CacheBackend. An entry larger than the whole cache is not stored, and the previous entry
+// for the key is removed.
+func (c *BoundedCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := &boundedEntry{
+		key:   key,
+		value: value,
+	}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.opts.MaxBytes > 0 && entry.size() > c.opts.MaxBytes {
+		// the previous value is outdated, so it mustn't be served in place of the rejected one
+		if previous, ok := c.entries[key]; ok {
+			c.remove(previous)
+		}
+		return fmt.Errorf("entry of %d bytes exceeds the cache size", entry.size())
+	}
+
+	if previous, ok := c.entries[key]; ok {
+		// keep the usage of the entry, so replacing it doesn't make it a candidate for eviction
+		entry.hits = previous.hits
+		c.remove(previous)
+	}
+
+	// evict before inserting, so the new entry is never the one evicted, even though it hasn't been used yet
+	for c.opts.MaxBytes > 0 && c.size+entry.size() > c.opts.MaxBytes {
+		c.remove(c.queue.entries[0])
+		c.evicted++
+	}
+
+	c.clock++
+	entry.lastUsed = c.clock
+	c.entries[key] = entry
+	heap.Push(&c.queue, entry)
+	c.size += entry.size()
+
+	return nil
+}
+
+// Delete implements CacheBackend.
+func (c *BoundedCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok {
+		c.remove(entry)
+	}
+
+	return nil
+}
+
+// Keys implements CacheInspector.
+func (c *BoundedCache) Keys(_ context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Flush implements CacheInspector.
+func (c *BoundedCache) Flush(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]*boundedEntry)
+	c.queue.entries = nil
+	c.size = 0
+
+	return nil
+}
+
+// Usage implements CacheInspector.
+func (c *BoundedCache) Usage(_ context.Context) (CacheUsage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheUsage{
+		Items:     len(c.entries),
+		Bytes:     c.size,
+		Evictions: c.evicted,
+	}, nil
+}
+
+// remove deletes the entry from the cache. It must be called with the lock held.
+func (c *BoundedCache) remove(entry *boundedEntry) {
+	heap.Remove(&c.queue, entry.index)
+	delete(c.entries, entry.key)
+	c.size -= entry.size()
+}
+
+// evictionQueue is a heap.Interface with the next entry to evict at the top.
+type evictionQueue struct {
+	policy  EvictionPolicy
+	entries []*boundedEntry
+}
+
+func (q *evictionQueue) Len() int {
+	return len(q.entries)
+}
+
+func (q *evictionQueue) Less(i, j int) bool {
+	a, b := q.entries[i], q.entries[j]
+	if q.policy == EvictLFU && a.hits != b.hits {
+		return a.hits < b.hits
+	}
+
+	return a.lastUsed < b.lastUsed
+}
+
+func (q *evictionQueue) Swap(i, j int) {
+	q.entries[i], q.entries[j] = q.entries[j], q.entries[i]
+	q.entries[i].index = i
+	q.entries[j].index = j
+}
+
+func (q *evictionQueue) Push(x any) {
+	entry := x.(*boundedEntry)
+	entry.index = len(q.entries)
+	q.entries = append(q.entries, entry)
+}
+
+func (q *evictionQueue) Pop() any {
+	n := len(q.entries)
+	entry := q.entries[n-1]
+	q.entries[n-1] = nil
+	q.entries = q.entries[:n-1]
+	return entry
+}
@@ -1,12 +1,30 @@
 package pokemon
 
 import (
+	"context"
+	"errors"
 	"github.com/patrickmn/go-cache"
 	"time"
 )
 
+// ErrCacheMiss is returned by a CacheBackend when there is no entry for a key, or the entry has expired.
+var ErrCacheMiss = errors.New("cache miss")
+
+// CacheBackend is a store for the response bodies of the Pokemon public API, keyed by the URL they were fetched from.
+// You can implement it to plug your own store into the Resolver through the Cache field of the Config.
+//
+// The Resolver treats every error returned by the backend as a cache miss, so a failing backend never fails a request.
+type CacheBackend interface {
+	// Get returns the value stored for the key or ErrCacheMiss if there is none.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores the value for the key. A ttl of zero or less means the entry doesn't expire.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the entry for the key. Deleting a key which doesn't exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
 // Cache is a wrapper around a github.com/patrickmn/go-cache in-memory cache. It is used to cache responses from the
-// Pokemon public API to reduce the HTTP traffic and latency.
+// Pokemon public API to reduce the HTTP traffic and latency. It is the default CacheBackend.
 type Cache struct {
 	cache *cache.Cache
 }
@@ -32,3 +50,61 @@ func (c *Cache) GetResponseBodyForURL(url string) []byte {
 func (c *Cache) CacheResponseForURL(url string, data []byte) {
 	c.cache.Set(url, data, 0)
 }
+
+// Get implements CacheBackend.
+func (c *Cache) Get(_ context.Context, key string) ([]byte, error) {
+	data := c.GetResponseBodyForURL(key)
+	if data == nil {
+		return nil, ErrCacheMiss
+	}
+
+	return data, nil
+}
+
+// Set implements CacheBackend.
+func (c *Cache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = cache.NoExpiration
+	}
+
+	c.cache.Set(key, value, ttl)
+	return nil
+}
+
+// Delete implements CacheBackend.
+func (c *Cache) Delete(_ context.Context, key string) error {
+	c.cache.Delete(key)
+	return nil
+}
+
+// Keys implements CacheInspector.
+func (c *Cache) Keys(_ context.Context) ([]string, error) {
+	items := c.cache.Items()
+
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Flush implements CacheInspector.
+func (c *Cache) Flush(_ context.Context) error {
+	c.cache.Flush()
+	return nil
+}
+
+// Usage implements CacheInspector. The Cache never evicts entries, it only removes the expired ones.
+func (c *Cache) Usage(_ context.Context) (CacheUsage, error) {
+	items := c.cache.Items()
+
+	usage := CacheUsage{Items: len(items)}
+	for key, item := range items {
+		if data, ok := item.Object.([]byte); ok {
+			usage.Bytes += int64(len(key) + len(data))
+		}
+	}
+
+	return usage, nil
+}
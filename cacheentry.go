@@ -0,0 +1,85 @@
+package pokemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// cacheEntryMagic prefixes the cache entries which carry metadata along with the response body. It can't be the start
+// of a JSON document, so plain response bodies, e.g. stored with Cache.CacheResponseForURL, are still read correctly.
+var cacheEntryMagic = []byte("pokemon-sdk/1\n")
+
+// cacheEntry is a response body stored in the cache, along with the time it stops being fresh. A stale entry can still
+// be served while it is refreshed, or if refreshing it fails, depending on the Config. The validators of the response
+// are kept so a stale entry can be revalidated without downloading it again.
+type cacheEntry struct {
+	body []byte
+	// zero if the entry never goes stale
+	freshUntil   time.Time
+	etag         string
+	lastModified string
+}
+
+type cacheEntryHeader struct {
+	// Unix time in nanoseconds
+	FreshUntil   int64  `json:"fresh_until,omitempty"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// hasValidators reports whether the entry can be revalidated with a conditional request.
+func (e cacheEntry) hasValidators() bool {
+	return e.etag != "" || e.lastModified != ""
+}
+
+func (e cacheEntry) isFresh(now time.Time) bool {
+	return e.freshUntil.IsZero() || now.Before(e.freshUntil)
+}
+
+// isStaleFor reports whether the entry has been stale for less than d.
+func (e cacheEntry) isStaleFor(now time.Time, d time.Duration) bool {
+	return !e.isFresh(now) && now.Before(e.freshUntil.Add(d))
+}
+
+func encodeCacheEntry(e cacheEntry) []byte {
+	header := cacheEntryHeader{ETag: e.etag, LastModified: e.lastModified}
+	if !e.freshUntil.IsZero() {
+		header.FreshUntil = e.freshUntil.UnixNano()
+	}
+
+	// a struct with basic fields can't fail to marshal
+	headerJSON, _ := json.Marshal(header)
+
+	data := make([]byte, 0, len(cacheEntryMagic)+len(headerJSON)+1+len(e.body))
+	data = append(data, cacheEntryMagic...)
+	data = append(data, headerJSON...)
+	data = append(data, '\n')
+	return append(data, e.body...)
+}
+
+func decodeCacheEntry(data []byte) (cacheEntry, error) {
+	if !bytes.HasPrefix(data, cacheEntryMagic) {
+		// a plain response body, fresh for as long as the backend keeps it
+		return cacheEntry{body: data}, nil
+	}
+
+	headerJSON, body, found := bytes.Cut(data[len(cacheEntryMagic):], []byte{'\n'})
+	if !found {
+		return cacheEntry{}, errors.New("malformed cache entry")
+	}
+
+	var header cacheEntryHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return cacheEntry{}, fmt.Errorf("malformed cache entry: %w", err)
+	}
+
+	entry := cacheEntry{body: body, etag: header.ETag, lastModified: header.LastModified}
+	if header.FreshUntil != 0 {
+		entry.freshUntil = time.Unix(0, header.FreshUntil)
+	}
+
+	return entry, nil
+}
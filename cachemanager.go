@@ -0,0 +1,147 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrCacheUnsupported is returned by the CacheManager when the cache backend doesn't implement CacheInspector.
+var ErrCacheUnsupported = errors.New("operation not supported by the cache backend")
+
+// CacheInspector is implemented by cache backends which can be inspected and managed through the CacheManager. All
+// the built-in backends implement it.
+type CacheInspector interface {
+	// Keys returns the keys of every entry currently stored.
+	Keys(ctx context.Context) ([]string, error)
+	// Flush removes every entry.
+	Flush(ctx context.Context) error
+	// Usage returns the current usage of the backend.
+	Usage(ctx context.Context) (CacheUsage, error)
+}
+
+// CacheUsage describes the current usage of a cache backend.
+type CacheUsage struct {
+	// The number of entries currently stored
+	Items int
+	// The size of the stored entries in bytes, zero if the backend can't tell
+	Bytes int64
+	// The number of entries removed to make room for new ones. Entries removed because they expired are not counted
+	Evictions uint64
+}
+
+// CacheStats contains the statistics of the cache used by a Resolver.
+type CacheStats struct {
+	// The number of lookups which found an entry
+	Hits uint64
+	// The number of lookups which didn't find an entry
+	Misses uint64
+	CacheUsage
+}
+
+// HitRatio returns the ratio of lookups which found an entry, or zero if there haven't been any lookups.
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(total)
+}
+
+// CacheManager gives access to the statistics and the entries of the cache used by a Resolver. If the cache is
+// disabled, the manager reports an empty cache.
+type CacheManager struct {
+	client *client
+}
+
+// Cache returns a CacheManager for the cache used by the Resolver.
+func (r *Resolver) Cache() *CacheManager {
+	return &CacheManager{client: r.client}
+}
+
+// Stats returns the hit and miss counters of the Resolver along with the usage reported by the cache backend. If the
+// backend doesn't implement CacheInspector, only the counters are returned.
+func (m *CacheManager) Stats(ctx context.Context) (CacheStats, error) {
+	stats := CacheStats{
+		Hits:   m.client.counters.hits.Load(),
+		Misses: m.client.counters.misses.Load(),
+	}
+
+	inspector, ok := m.client.cache.(CacheInspector)
+	if !ok {
+		return stats, nil
+	}
+
+	usage, err := inspector.Usage(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.CacheUsage = usage
+
+	return stats, nil
+}
+
+// Keys returns the keys, i.e. URLs, of every cached response.
+func (m *CacheManager) Keys(ctx context.Context) ([]string, error) {
+	if m.client.cache == nil {
+		return nil, nil
+	}
+
+	inspector, ok := m.client.cache.(CacheInspector)
+	if !ok {
+		return nil, ErrCacheUnsupported
+	}
+
+	return inspector.Keys(ctx)
+}
+
+// Delete removes the cached response for the key.
+func (m *CacheManager) Delete(ctx context.Context, key string) error {
+	if m.client.cache == nil {
+		return nil
+	}
+
+	return m.client.cache.Delete(ctx, key)
+}
+
+// PurgePrefix removes every cached response with a key starting with the prefix and returns the number of removed
+// entries. If the prefix starts with a slash, it is relative to the base URL e.g., "/pokemon/" removes all Pokemon.
+func (m *CacheManager) PurgePrefix(ctx context.Context, prefix string) (int, error) {
+	if strings.HasPrefix(prefix, "/") {
+		prefix = m.client.baseURL + prefix
+	}
+
+	keys, err := m.Keys(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var purged int
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if err := m.client.cache.Delete(ctx, key); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// Flush removes every cached response.
+func (m *CacheManager) Flush(ctx context.Context) error {
+	if m.client.cache == nil {
+		return nil
+	}
+
+	inspector, ok := m.client.cache.(CacheInspector)
+	if !ok {
+		return ErrCacheUnsupported
+	}
+
+	return inspector.Flush(ctx)
+}
@@ -3,23 +3,60 @@ package pokemon
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/boyski33/pokemon-sdk/v2/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 )
 
 type client struct {
 	baseURL    string
 	httpClient *http.Client
-	cache      *Cache
+	cache      CacheBackend
+	cacheTTL   time.Duration
+	// the time-to-live of list pages, which may differ from the one of single resources
+	listCacheTTL time.Duration
+	// how long stale entries are served while being refreshed, or when refreshing fails
+	staleWhileRevalidate time.Duration
+	staleIfError         time.Duration
+	// how long expired entries with validators are kept to be revalidated
+	revalidationWindow time.Duration
+	flights            flightGroup
+	// never nil, discards everything unless Config.Logger is set
+	logger *slog.Logger
+	// never nil, doesn't record anything unless Config.TracerProvider is set
+	tracer trace.Tracer
+	// never nil, doesn't record anything unless Config.Metrics is set
+	metrics Metrics
+	// shared by the clones of a Resolver which share the cache
+	counters *cacheCounters
 }
 
-func newClient(baseURL string, cl *http.Client, c *Cache) *client {
+// cacheCounters count the cache lookups of a client.
+type cacheCounters struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func newClient(baseURL string, cl *http.Client, c CacheBackend) *client {
 	return &client{
 		baseURL:    baseURL,
 		httpClient: cl,
 		cache:      c,
+		logger:     slog.New(slog.DiscardHandler),
+		tracer:     noop.NewTracerProvider().Tracer(tracerName),
+		metrics:    noopMetrics{},
+		counters:   &cacheCounters{},
 	}
 }
 
@@ -27,149 +64,412 @@ func newClient(baseURL string, cl *http.Client, c *Cache) *client {
 //
 // A model.ErrNotFound is returned if the Pokemon does not exist.
 func (c *client) GetPokemonByIDOrName(ctx context.Context, idOrName string) (*model.Pokemon, error) {
-	url := fmt.Sprintf("%s/pokemon/%s", c.baseURL, idOrName)
-
 	var result model.Pokemon
-	if err := c.loadFromCache(url, &result); err == nil {
-		return &result, nil
+	if err := c.getResource(ctx, "pokemon", idOrName, &result); err != nil {
+		return nil, err
 	}
 
-	body, err := c.fetchFromURL(ctx, url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch generation: %w", err)
-	}
-
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
+	return &result, nil
+}
 
-	c.saveToCache(url, body)
+// GetPokemonList returns a single page of the Pokemon list, starting from offset, or an error.
+func (c *client) GetPokemonList(ctx context.Context, limit, offset int) (*model.NamedResourceList, error) {
+	return c.getList(ctx, "pokemon", limit, offset)
+}
 
-	return &result, nil
+// GetAllPokemonNames returns the full index of Pokemon.
+func (c *client) GetAllPokemonNames(ctx context.Context) ([]model.NamedResource, error) {
+	return c.getIndex(ctx, "pokemon")
 }
 
-// GetPokemonNamesList return a list of Pokemon names, a flag if there are more Pokemon to be fetched and an error.
-func (c *client) GetPokemonNamesList(ctx context.Context, limit, offset int) (names []string, hasMore bool, err error) {
-	type response struct {
-		Count   int                   `json:"count"`
-		Results []model.NamedResource `json:"results"`
+// GetGenerationByIDOrName return a model.Generation pointer for the provided identifier or an error.
+//
+// A model.ErrNotFound is returned if the Generation does not exist.
+func (c *client) GetGenerationByIDOrName(ctx context.Context, idOrName string) (*model.Generation, error) {
+	var result model.Generation
+	if err := c.getResource(ctx, "generation", idOrName, &result); err != nil {
+		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/pokemon?limit=%d&offset=%d", c.baseURL, limit, offset)
+	return &result, nil
+}
 
-	var resp response
+// GetGenerationList returns a single page of the Generations list, starting from offset, or an error.
+func (c *client) GetGenerationList(ctx context.Context, limit, offset int) (*model.NamedResourceList, error) {
+	return c.getList(ctx, "generation", limit, offset)
+}
 
-	body, err := c.fetchFromURL(ctx, url)
-	if err != nil {
-		return nil, false, fmt.Errorf("failed to fetch generation: %w", err)
+// GetAllGenerationNames returns the full index of Generations.
+func (c *client) GetAllGenerationNames(ctx context.Context) ([]model.NamedResource, error) {
+	return c.getIndex(ctx, "generation")
+}
+
+// getResource loads the resource with the identifier into v, from the cache if possible. Identifiers are normalized,
+// so e.g. "Pikachu " and "pikachu" share a cache entry. Whenever the response is fetched, including by a background
+// refresh, it is also cached under the ID and the name of the resource, so a later lookup by either of them hits the
+// cache and serves the same version.
+func (c *client) getResource(ctx context.Context, resource, idOrName string, v any) (err error) {
+	ctx, span := c.tracer.Start(ctx, resource+".get", trace.WithAttributes(
+		attrResourceType.String(resource),
+		attrResourceID.String(normalizeIdentifier(idOrName)),
+	))
+	defer func() { endSpan(span, err) }()
+
+	url := c.resourceURL(resource, idOrName)
+
+	storeAliases := func(ctx context.Context, entry cacheEntry) {
+		c.storeAliases(ctx, resource, url, entry)
+	}
+	if err := c.load(ctx, url, c.cacheTTL, v, storeAliases); err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", resource, err)
 	}
 
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, false, fmt.Errorf("failed to unmarshal response: %w", err)
+	return nil
+}
+
+// storeAliases caches the fetched response of the resource at url under its ID and name as well, along with its
+// validators, so the aliases can be revalidated like the response itself.
+func (c *client) storeAliases(ctx context.Context, resource, url string, entry cacheEntry) {
+	var identity struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if json.Unmarshal(entry.body, &identity) != nil {
+		return
 	}
 
-	hasMore = resp.Count > offset+limit
+	for _, alias := range []string{strconv.Itoa(identity.ID), identity.Name} {
+		if alias == "" || alias == "0" {
+			continue
+		}
 
-	names = make([]string, len(resp.Results))
-	for i, res := range resp.Results {
-		names[i] = res.Name
+		if aliasURL := c.resourceURL(resource, alias); aliasURL != url {
+			c.store(ctx, aliasURL, cacheEntry{body: entry.body, etag: entry.etag, lastModified: entry.lastModified}, c.cacheTTL)
+		}
 	}
+}
 
-	return names, hasMore, nil
+// resourceURL returns the canonical URL of a single resource.
+func (c *client) resourceURL(resource, idOrName string) string {
+	return fmt.Sprintf("%s/%s/%s", c.baseURL, resource, url.PathEscape(normalizeIdentifier(idOrName)))
 }
 
-// GetGenerationByIDOrName return a model.Generation pointer for the provided identifier or an error.
-//
-// A model.ErrNotFound is returned if the Generation does not exist.
-func (c *client) GetGenerationByIDOrName(ctx context.Context, idOrName string) (*model.Generation, error) {
-	url := fmt.Sprintf("%s/generation/%s", c.baseURL, idOrName)
+// normalizeIdentifier returns the canonical form of an ID or name. The API only uses lowercase names.
+func normalizeIdentifier(idOrName string) string {
+	return strings.ToLower(strings.TrimSpace(idOrName))
+}
 
-	var result model.Generation
-	if err := c.loadFromCache(url, &result); err == nil {
-		return &result, nil
+func (c *client) getList(ctx context.Context, resource string, limit, offset int) (_ *model.NamedResourceList, err error) {
+	ctx, span := c.tracer.Start(ctx, resource+".list", trace.WithAttributes(
+		attrResourceType.String(resource),
+		attribute.Int("pokemon.list.limit", limit),
+		attribute.Int("pokemon.list.offset", offset),
+	))
+	defer func() { endSpan(span, err) }()
+
+	url := fmt.Sprintf("%s/%s?limit=%d&offset=%d", c.baseURL, resource, limit, offset)
+
+	var result model.NamedResourceList
+	if err := c.load(ctx, url, c.listCacheTTL, &result, nil); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s list: %w", resource, err)
 	}
 
-	body, err := c.fetchFromURL(ctx, url)
+	return &result, nil
+}
+
+// getIndex returns every named resource of the given type. It makes one small request to find out the total count
+// and then fetches all of them as a single page. Both requests are cached like any other list page.
+func (c *client) getIndex(ctx context.Context, resource string) ([]model.NamedResource, error) {
+	probe, err := c.getList(ctx, resource, 1, 0)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch generation: %w", err)
+		return nil, err
 	}
 
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	if probe.Count == 0 {
+		return []model.NamedResource{}, nil
 	}
 
-	c.saveToCache(url, body)
+	all, err := c.getList(ctx, resource, probe.Count, 0)
+	if err != nil {
+		return nil, err
+	}
 
-	return &result, nil
+	return all.Results, nil
 }
 
-// GetGenerationNamesList return a list of Generation names, a flag if there are more to be fetched and an error.
-func (c *client) GetGenerationNamesList(ctx context.Context, limit, offset int) (names []string, hasMore bool, err error) {
-	type response struct {
-		Count   int                   `json:"count"`
-		Results []model.NamedResource `json:"results"`
+// load decodes the response for the URL into v. The response is served from the cache if possible, otherwise it is
+// fetched and cached with the specified ttl. If fetched is not nil, it is called with every response fetched for the
+// URL, including by background refreshes, see fetch.
+//
+// Depending on the Config, a stale response is served while it is refreshed in the background, or if fetching fails.
+func (c *client) load(ctx context.Context, url string, ttl time.Duration, v any, fetched func(context.Context, cacheEntry)) error {
+	now := time.Now()
+
+	entry, found := c.lookupTraced(ctx, url, now)
+	if found && (entry.isFresh(now) || entry.isStaleFor(now, c.staleWhileRevalidate)) {
+		if err := c.decode(ctx, entry.body, v); err == nil {
+			c.counters.hits.Add(1)
+			c.metrics.CacheLookup(endpointOf(c.baseURL, url), true)
+			c.logger.DebugContext(ctx, "cache hit", "url", url, "stale", !entry.isFresh(now))
+			if !entry.isFresh(now) {
+				c.refreshInBackground(url, ttl, fetched)
+			}
+			return nil
+		}
 	}
 
-	url := fmt.Sprintf("%s/generation?limit=%d&offset=%d", c.baseURL, limit, offset)
-
-	var resp response
+	if c.cache != nil {
+		c.counters.misses.Add(1)
+		c.metrics.CacheLookup(endpointOf(c.baseURL, url), false)
+		c.logger.DebugContext(ctx, "cache miss", "url", url)
+	}
 
-	body, err := c.fetchFromURL(ctx, url)
+	body, err := c.fetch(ctx, url, ttl, fetched)
 	if err != nil {
-		return nil, false, fmt.Errorf("failed to fetch generation: %w", err)
+		if found && ctx.Err() == nil && isServerFailure(err) && entry.isStaleFor(now, c.staleIfError) {
+			if c.decode(ctx, entry.body, v) == nil {
+				c.logger.WarnContext(ctx, "serving stale response", "url", url, "error", err)
+				trace.SpanFromContext(ctx).SetAttributes(attrCacheOutcome.String(cacheOutcomeStale))
+				return nil
+			}
+		}
+		return err
 	}
 
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, false, fmt.Errorf("failed to unmarshal response: %w", err)
+	return c.decode(ctx, body, v)
+}
+
+// lookupTraced looks the URL up in the cache, within a span. The outcome of the lookup is also recorded on the span of
+// the calling operation.
+func (c *client) lookupTraced(ctx context.Context, url string, now time.Time) (cacheEntry, bool) {
+	if c.cache == nil {
+		trace.SpanFromContext(ctx).SetAttributes(attrCacheOutcome.String(cacheOutcomeDisabled))
+		return cacheEntry{}, false
 	}
 
-	hasMore = resp.Count > offset+limit
+	lookupCtx, span := c.tracer.Start(ctx, "cache.lookup", trace.WithAttributes(attrURL.String(url)))
+	entry, found := c.lookup(lookupCtx, url)
 
-	names = make([]string, len(resp.Results))
-	for i, res := range resp.Results {
-		names[i] = res.Name
+	outcome := cacheOutcomeMiss
+	switch {
+	case found && entry.isFresh(now):
+		outcome = cacheOutcomeHit
+	case found && entry.isStaleFor(now, c.staleWhileRevalidate):
+		outcome = cacheOutcomeStale
 	}
+	span.SetAttributes(attrCacheOutcome.String(outcome))
+	span.End()
+	trace.SpanFromContext(ctx).SetAttributes(attrCacheOutcome.String(outcome))
 
-	return names, hasMore, nil
+	return entry, found
 }
 
-func (c *client) fetchFromURL(ctx context.Context, url string) ([]byte, error) {
+// decode unmarshals the response body into v, within a span.
+func (c *client) decode(ctx context.Context, body []byte, v any) (err error) {
+	_, span := c.tracer.Start(ctx, "decode", trace.WithAttributes(attrBodySize.Int(len(body))))
+	defer func() { endSpan(span, err) }()
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// fetch returns the response body for the URL and caches it with the specified ttl. Concurrent fetches of the same URL
+// are coalesced into a single request. If the URL is cached with validators, the request is conditional, and a
+// "304 Not Modified" response refreshes the cached entry instead of downloading it again. If fetched is not nil, it is
+// called with the cached entry, so it can be cached under other keys as well.
+func (c *client) fetch(ctx context.Context, url string, ttl time.Duration, fetched func(context.Context, cacheEntry)) ([]byte, error) {
+	return c.flights.Do(ctx, url, func(ctx context.Context) ([]byte, error) {
+		cached, found := c.lookup(ctx, url)
+		if !found || !json.Valid(cached.body) {
+			cached = cacheEntry{}
+		}
+
+		entry, err := c.fetchFromURL(ctx, url, cached)
+		if err != nil {
+			return nil, err
+		}
+
+		if json.Valid(entry.body) {
+			c.store(ctx, url, entry, ttl)
+			if fetched != nil {
+				fetched(ctx, entry)
+			}
+		}
+
+		return entry.body, nil
+	})
+}
+
+// refreshInBackground fetches the URL again without making the caller wait for it.
+func (c *client) refreshInBackground(url string, ttl time.Duration, fetched func(context.Context, cacheEntry)) {
+	go func() {
+		if _, err := c.fetch(context.Background(), url, ttl, fetched); err != nil {
+			c.logger.Warn("background refresh failed", "url", url, "error", err)
+		}
+	}()
+}
+
+// fetchFromURL requests the URL, conditionally if the cached entry has validators. On "304 Not Modified" the cached
+// body is returned.
+func (c *client) fetchFromURL(ctx context.Context, url string, cached cacheEntry) (_ cacheEntry, err error) {
+	start := time.Now()
+
+	ctx, span := c.tracer.Start(ctx, "http.fetch", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attrURL.String(url),
+		attribute.Bool("http.request.conditional", cached.hasValidators()),
+	))
+	defer func() { endSpan(span, err) }()
+
+	// the status code and the size of the response, if there is one
+	var status, size int
+	endpoint := endpointOf(c.baseURL, url)
+	c.metrics.RequestStarted(endpoint)
+	defer func() { c.metrics.RequestFinished(endpoint, status, time.Since(start), size) }()
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return cacheEntry{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	if cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+	if cached.lastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.lastModified)
 	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		c.logger.WarnContext(ctx, "request failed", "url", url, "duration", time.Since(start), "error", err)
+		return cacheEntry{}, fmt.Errorf("failed to send request: %w", err)
 	}
 
 	defer resp.Body.Close()
 
+	status = resp.StatusCode
+	span.SetAttributes(attrStatusCode.Int(resp.StatusCode))
+
+	if resp.StatusCode == http.StatusNotModified && cached.hasValidators() {
+		c.logger.DebugContext(ctx, "response not modified", "url", url, "status", resp.StatusCode, "duration", time.Since(start))
+		return cacheEntry{
+			body:         cached.body,
+			etag:         headerOr(resp.Header, "ETag", cached.etag),
+			lastModified: headerOr(resp.Header, "Last-Modified", cached.lastModified),
+		}, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		if resp.StatusCode == http.StatusNotFound {
-			return nil, model.ErrNotFound
+			c.logger.DebugContext(ctx, "resource not found", "url", url, "status", resp.StatusCode, "duration", time.Since(start))
+			return cacheEntry{}, model.ErrNotFound
+		}
+		c.logger.WarnContext(ctx, "unexpected status code", "url", url, "status", resp.StatusCode, "duration", time.Since(start))
+		return cacheEntry{}, &statusError{code: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.logger.WarnContext(ctx, "failed to read response", "url", url, "status", resp.StatusCode, "duration", time.Since(start), "error", err)
+		return cacheEntry{}, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	size = len(body)
+	span.SetAttributes(attrBodySize.Int(len(body)))
+	c.logger.DebugContext(ctx, "fetched", "url", url, "status", resp.StatusCode, "duration", time.Since(start), "bytes", len(body))
+
+	return cacheEntry{
+		body:         body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// headerOr returns the value of the header or fallback if it isn't set.
+func headerOr(h http.Header, key, fallback string) string {
+	if v := h.Get(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// lookup returns the cache entry for the URL, if there is one.
+func (c *client) lookup(ctx context.Context, url string) (cacheEntry, bool) {
+	if c.cache == nil {
+		return cacheEntry{}, false
+	}
+
+	data, err := c.cache.Get(ctx, url)
+	if err != nil {
+		if !errors.Is(err, ErrCacheMiss) {
+			c.logger.WarnContext(ctx, "cache lookup failed", "url", url, "error", err)
 		}
-		return nil, fmt.Errorf("something went wrong")
+		return cacheEntry{}, false
+	}
+
+	entry, err := decodeCacheEntry(data)
+	if err != nil {
+		c.logger.WarnContext(ctx, "malformed cache entry", "url", url, "error", err)
+		return cacheEntry{}, false
 	}
 
-	return io.ReadAll(resp.Body)
+	return entry, true
 }
 
-func (c *client) loadFromCache(url string, v any) error {
+// store caches the response for the URL, encoded as described in encode.
+func (c *client) store(ctx context.Context, url string, entry cacheEntry, ttl time.Duration) {
 	if c.cache == nil {
-		return fmt.Errorf("cache disabled")
+		return
 	}
 
-	data := c.cache.GetResponseBodyForURL(url)
-	if data == nil {
-		return fmt.Errorf("cache miss")
+	data, backendTTL := c.encode(entry, ttl)
+
+	// a failing cache shouldn't fail the request, the response is simply not cached
+	if err := c.cache.Set(ctx, url, data, backendTTL); err != nil {
+		c.logger.WarnContext(ctx, "failed to cache response", "url", url, "error", err)
 	}
+}
 
-	return json.Unmarshal(data, v)
+// encode returns the value stored in the cache for the entry, and how long the backend should keep it. If stale
+// responses may be served, the entry is kept in the cache for longer than the ttl, along with the time it goes stale.
+// Responses with validators are kept for the revalidation window, since an expired entry can still be revalidated with
+// a cheap conditional request.
+func (c *client) encode(entry cacheEntry, ttl time.Duration) ([]byte, time.Duration) {
+	data, backendTTL := entry.body, ttl
+	if ttl > 0 && (c.staleWhileRevalidate > 0 || c.staleIfError > 0 || entry.hasValidators()) {
+		entry.freshUntil = time.Now().Add(ttl)
+		data = encodeCacheEntry(entry)
+		window := max(c.staleWhileRevalidate, c.staleIfError)
+		if entry.hasValidators() {
+			window = max(window, c.revalidationWindow)
+		}
+		backendTTL = ttl + window
+	}
+
+	return data, backendTTL
 }
 
-func (c *client) saveToCache(url string, data []byte) {
-	if c.cache != nil {
-		c.cache.CacheResponseForURL(url, data)
+// statusError is returned when the API responds with an unexpected status code.
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d", e.code)
+}
+
+// isServerFailure reports whether the error is caused by the API failing or being unreachable, as opposed to the
+// request being invalid.
+func isServerFailure(err error) bool {
+	if errors.Is(err, model.ErrNotFound) {
+		return false
 	}
+
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code >= http.StatusInternalServerError
+	}
+
+	return true
 }
@@ -1,10 +1,18 @@
 package pokemon
 
-import "time"
+import (
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"net/http"
+	"time"
+)
 
 const (
 	defaultClientTimeout = 10 * time.Second
 	defaultBaseURL       = "https://pokeapi.co/api/v2"
+	defaultBatchWorkers  = 8
+	// how long responses with validators are kept after they expire, so they can be revalidated
+	defaultRevalidationWindow = 24 * time.Hour
 )
 
 type Config struct {
@@ -12,8 +20,100 @@ type Config struct {
 	BaseURL string
 	// The timeout when making HTTP requests to the API
 	ClientTimeout time.Duration
+	// The HTTP client making the requests, if you want to configure it yourself e.g., its transport. It is copied, so
+	// the Resolver doesn't change it. ClientTimeout still applies if set
+	HTTPClient *http.Client
+	// Wrappers of the transport of the HTTP client, applied in order to every request e.g., pokemon.UserAgent
+	Middleware []Middleware
 	// If you want in-memory caching enabled
 	CacheEnabled bool
 	// The time-to-live of the cache entries
 	CacheTTL time.Duration
+	// The time-to-live of cached list pages, if you want it to differ from CacheTTL
+	ListCacheTTL time.Duration
+	// How long an expired entry is still served while it is refreshed in the background. Requires a CacheTTL
+	StaleWhileRevalidate time.Duration
+	// How long an expired entry is still served when refreshing it fails because the API is down. Requires a CacheTTL
+	StaleIfError time.Duration
+	// How long an expired entry with an ETag or a Last-Modified header is kept, so it can be revalidated with a
+	// conditional request instead of being downloaded again. Defaults to 24 hours. Requires a CacheTTL
+	RevalidationWindow time.Duration
+	// A custom cache backend e.g., your own store. Setting it enables caching regardless of CacheEnabled
+	Cache CacheBackend
+	// If you want responses cached on disk in this directory instead of in memory, so they survive restarts
+	CacheDir string
+	// If you want the responses cached on disk compressed with gzip
+	CacheCompression bool
+	// The maximum size of the cache in bytes, entries are evicted when it is exceeded. Applies to both the in-memory
+	// and the disk cache
+	CacheMaxBytes int64
+	// Which entries of the in-memory cache are evicted first when CacheMaxBytes is exceeded. The disk cache always
+	// evicts the least recently used entries
+	CacheEviction EvictionPolicy
+	// A snapshot to serve all responses from, without making any requests. Responses missing from the snapshot result
+	// in model.ErrNotFound. Overrides the other cache settings
+	Snapshot *Snapshot
+	// A local checkout of the PokeAPI api-data repository (https://github.com/PokeAPI/api-data) to read all responses
+	// from, instead of making requests
+	APIDataDir string
+	// A directory of recorded responses, so tests don't depend on the API. Depending on FixturesMode, responses are
+	// served from the fixtures or recorded into them
+	FixturesDir string
+	// Whether to replay or record the fixtures in FixturesDir. Can be overridden with the FixturesEnv variable
+	FixturesMode FixturesMode
+	// If you want the requests and the cache lookups logged. Requests and cache hits and misses are logged at debug
+	// level, failures at warn level
+	Logger *slog.Logger
+	// If you want the SDK operations traced with OpenTelemetry. Every Get or list page is a span, with child spans for
+	// the cache lookup, the HTTP request and decoding the response
+	TracerProvider trace.TracerProvider
+	// If you want the requests and the cache lookups measured e.g., with a pokemonprom.Collector
+	Metrics Metrics
+	// The maximum number of concurrent requests made by batch fetches e.g., Resolver.PokemonBatch
+	BatchWorkers int
+}
+
+// cacheFromConfig returns the CacheBackend described by the config or nil if caching is disabled.
+func cacheFromConfig(config Config) CacheBackend {
+	switch {
+	case config.Snapshot != nil:
+		baseURL := config.BaseURL
+		if baseURL == "" {
+			baseURL = defaultBaseURL
+		}
+		return &snapshotCache{snapshot: config.Snapshot, baseURL: baseURL}
+	case config.Cache != nil:
+		return config.Cache
+	case config.CacheDir != "":
+		return NewDiskCache(config.CacheDir, DiskCacheOptions{
+			Compress: config.CacheCompression,
+			MaxBytes: config.CacheMaxBytes,
+		})
+	case !config.CacheEnabled:
+		return nil
+	case config.CacheMaxBytes > 0:
+		return NewBoundedCache(BoundedCacheOptions{
+			MaxBytes: config.CacheMaxBytes,
+			Policy:   config.CacheEviction,
+		})
+	default:
+		return NewCache(config.CacheTTL)
+	}
+}
+
+// transportFromConfig returns the transport described by the config, wrapping base, the transport of the HTTP client.
+func transportFromConfig(config Config, baseURL string, base http.RoundTripper) http.RoundTripper {
+	transport := base
+	switch {
+	case config.Snapshot != nil:
+		transport = offlineTransport{}
+	case config.APIDataDir != "":
+		transport = newAPIDataTransport(config.APIDataDir, baseURL)
+	}
+
+	if config.FixturesDir != "" {
+		transport = newFixtureTransport(config.FixturesDir, fixturesModeFromEnv(config.FixturesMode), baseURL, transport)
+	}
+
+	return chainMiddleware(transport, config.Middleware)
 }
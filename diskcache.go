@@ -0,0 +1,393 @@
+package pokemon
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const diskCacheEntryExt = ".entry"
+
+// DiskCacheOptions contains the optional settings of a DiskCache.
+type DiskCacheOptions struct {
+	// If you want the stored response bodies compressed with gzip
+	Compress bool
+	// The maximum total size of the cache files in bytes. When exceeded, the least recently used entries are evicted.
+	// Zero means there is no limit
+	MaxBytes int64
+}
+
+// DiskCache is a CacheBackend storing responses on the filesystem, so they survive process restarts. Every entry is
+// kept in its own file, named after the hash of the key, along with its expiration time.
+//
+// Files are written atomically, so a DiskCache directory can safely be shared between processes, although the size
+// limit is only enforced per DiskCache.
+type DiskCache struct {
+	dir  string
+	opts DiskCacheOptions
+
+	mu      sync.Mutex
+	size    int64
+	evicted uint64
+}
+
+// diskCacheHeader is the first line of every entry file.
+type diskCacheHeader struct {
+	Key string `json:"key"`
+	// Unix time in nanoseconds, zero if the entry doesn't expire
+	Expires    int64 `json:"expires,omitempty"`
+	Compressed bool  `json:"compressed,omitempty"`
+}
+
+// NewDiskCache creates a new DiskCache storing entries in the specified directory, which is created if it doesn't
+// exist.
+func NewDiskCache(dir string, opts DiskCacheOptions) *DiskCache {
+	c := &DiskCache{
+		dir:  dir,
+		opts: opts,
+	}
+
+	// best effort, a missing or unreadable directory means an empty cache
+	entries, _ := c.entries()
+	for _, entry := range entries {
+		c.size += entry.size
+	}
+
+	return c
+}
+
+// Get implements CacheBackend.
+func (c *DiskCache) Get(_ context.Context, key string) ([]byte, error) {
+	path := c.path(key)
+
+	header, body, err := readDiskCacheEntry(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Key != key {
+		return nil, ErrCacheMiss
+	}
+
+	if header.Expires != 0 && time.Now().UnixNano() > header.Expires {
+		_ = c.remove(path)
+		return nil, ErrCacheMiss
+	}
+
+	// the modification time is used to find the least recently used entries
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return body, nil
+}
+
+// Set implements CacheBackend.
+func (c *DiskCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	header := diskCacheHeader{
+		Key:        key,
+		Compressed: c.opts.Compress,
+	}
+	if ttl > 0 {
+		header.Expires = time.Now().Add(ttl).UnixNano()
+	}
+
+	data, err := encodeDiskCacheEntry(header, value)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path := c.path(key)
+	var previous int64
+	if info, err := os.Stat(path); err == nil {
+		previous = info.Size()
+	}
+
+	if err := writeFileAtomic(c.dir, path, data); err != nil {
+		return err
+	}
+
+	c.size += int64(len(data)) - previous
+	c.evict()
+
+	return nil
+}
+
+// Delete implements CacheBackend.
+func (c *DiskCache) Delete(_ context.Context, key string) error {
+	return c.remove(c.path(key))
+}
+
+// Keys implements CacheInspector.
+func (c *DiskCache) Keys(_ context.Context) ([]string, error) {
+	entries, err := c.entries()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		header, err := readDiskCacheHeader(entry.path)
+		if err != nil {
+			// removed concurrently or not written by a DiskCache
+			continue
+		}
+		keys = append(keys, header.Key)
+	}
+
+	return keys, nil
+}
+
+// Flush implements CacheInspector.
+func (c *DiskCache) Flush(_ context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := c.entries()
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := os.Remove(entry.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		c.size -= entry.size
+	}
+
+	return nil
+}
+
+// Usage implements CacheInspector.
+func (c *DiskCache) Usage(_ context.Context) (CacheUsage, error) {
+	entries, err := c.entries()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return CacheUsage{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheUsage{
+		Items:     len(entries),
+		Bytes:     c.size,
+		Evictions: c.evicted,
+	}, nil
+}
+
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+diskCacheEntryExt)
+}
+
+func (c *DiskCache) remove(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	info, err := os.Stat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	c.size -= info.Size()
+
+	return nil
+}
+
+// evict removes the least recently used entries until the cache fits in MaxBytes. It must be called with the lock held.
+func (c *DiskCache) evict() {
+	if c.opts.MaxBytes <= 0 || c.size <= c.opts.MaxBytes {
+		return
+	}
+
+	entries, err := c.entries()
+	if err != nil {
+		return
+	}
+
+	slices.SortFunc(entries, func(a, b diskCacheEntry) int {
+		return a.modified.Compare(b.modified)
+	})
+
+	for _, entry := range entries {
+		if c.size <= c.opts.MaxBytes {
+			return
+		}
+
+		if err := os.Remove(entry.path); err == nil {
+			c.size -= entry.size
+			c.evicted++
+		}
+	}
+}
+
+type diskCacheEntry struct {
+	path     string
+	size     int64
+	modified time.Time
+}
+
+func (c *DiskCache) entries() ([]diskCacheEntry, error) {
+	dirEntries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]diskCacheEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), diskCacheEntryExt) {
+			continue
+		}
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			continue
+		}
+
+		entries = append(entries, diskCacheEntry{
+			path:     filepath.Join(c.dir, dirEntry.Name()),
+			size:     info.Size(),
+			modified: info.ModTime(),
+		})
+	}
+
+	return entries, nil
+}
+
+func encodeDiskCacheEntry(header diskCacheHeader, value []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(header); err != nil {
+		return nil, fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	if !header.Compressed {
+		buf.Write(value)
+		return buf.Bytes(), nil
+	}
+
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(value); err != nil {
+		return nil, fmt.Errorf("failed to compress cache entry: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress cache entry: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func readDiskCacheEntry(path string) (diskCacheHeader, []byte, error) {
+	var header diskCacheHeader
+
+	f, err := os.Open(path)
+	if err != nil {
+		return header, nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header, err = decodeDiskCacheHeader(r)
+	if err != nil {
+		return header, nil, err
+	}
+
+	var body io.Reader = r
+	if header.Compressed {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return header, nil, fmt.Errorf("failed to decompress cache entry: %w", err)
+		}
+		defer zr.Close()
+		body = zr
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return header, nil, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+
+	return header, data, nil
+}
+
+func readDiskCacheHeader(path string) (diskCacheHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return diskCacheHeader{}, err
+	}
+	defer f.Close()
+
+	return decodeDiskCacheHeader(bufio.NewReader(f))
+}
+
+func decodeDiskCacheHeader(r *bufio.Reader) (diskCacheHeader, error) {
+	var header diskCacheHeader
+
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return header, fmt.Errorf("failed to read cache entry: %w", err)
+	}
+	if err := json.Unmarshal(line, &header); err != nil {
+		return header, fmt.Errorf("failed to decode cache entry: %w", err)
+	}
+
+	return header, nil
+}
+
+// writeFileAtomic writes the data to a temporary file in dir and renames it to path, so readers never see a partially
+// written file.
+func writeFileAtomic(dir, path string, data []byte) error {
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to rename temporary file: %w", err)
+	}
+
+	return nil
+}
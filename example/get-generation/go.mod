@@ -6,4 +6,8 @@ replace github.com/boyski33/pokemon-sdk/v2 => ../..
 
 require github.com/boyski33/pokemon-sdk/v2 v2.0.0-00010101000000-000000000000
 
-require github.com/patrickmn/go-cache v2.1.0+incompatible // indirect
+require (
+	github.com/patrickmn/go-cache v2.1.0+incompatible // indirect
+	go.opentelemetry.io/otel v1.38.0 // indirect
+	go.opentelemetry.io/otel/trace v1.38.0 // indirect
+)
@@ -8,11 +8,11 @@ import (
 )
 
 func main() {
-	resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
-		ClientTimeout: 10 * time.Second,
-		CacheEnabled:  true,
-		CacheTTL:      1 * time.Minute,
-	})
+	resolver := pokemon.NewResolver(
+		pokemon.WithClientTimeout(10*time.Second),
+		pokemon.WithCacheEnabled(true),
+		pokemon.WithCacheTTL(1*time.Minute),
+	)
 
 	start := time.Now()
 
@@ -0,0 +1,88 @@
+package pokemon
+
+import (
+	"context"
+	"sync"
+)
+
+// flightGroup coalesces concurrent fetches of the same key, so that only one request is in flight per key and all
+// callers share its result.
+//
+// The shared fetch is detached from the context of the caller which started it. It is only cancelled once every
+// waiting caller has given up, so a cancelled leader does not fail the requests of the remaining callers.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+
+	body []byte
+	err  error
+}
+
+// Do executes and returns the result of fn for the given key, making sure only one execution is in flight at a time.
+// If a duplicate call comes in, the caller waits for the original one to complete and receives the same result.
+//
+// The returned byte slice is shared between callers and must not be modified.
+func (g *flightGroup) Do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+
+	call, ok := g.calls[key]
+	if !ok {
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+		g.calls[key] = call
+
+		go g.run(fetchCtx, key, call, fn)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.body, call.err
+	case <-ctx.Done():
+		g.leave(key, call)
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, fn func(ctx context.Context) ([]byte, error)) {
+	call.body, call.err = fn(ctx)
+
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+
+	call.cancel()
+	close(call.done)
+}
+
+// leave removes a waiter from the call. The last waiter to leave cancels the shared fetch and forgets the call, so that
+// subsequent callers start a fresh request instead of joining a cancelled one.
+func (g *flightGroup) leave(key string, call *flightCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+
+	call.cancel()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
@@ -0,0 +1,94 @@
+package pokemon
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"iter"
+)
+
+// All returns an iterator over the names in the list, starting from the page the cursor points to up to the last
+// one. The next page is prefetched while the current one is being consumed. If a page fails to fetch, the error is
+// yielded and the iteration stops.
+//
+// All doesn't move the cursor used by Next, so the iterator can be used more than once.
+func (l *List) All(ctx context.Context) iter.Seq2[string, error] {
+	// a copy, so the iteration is independent of subsequent calls to Next
+	list := *l
+
+	return func(yield func(string, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		// cancels the prefetch if the caller stops early
+		defer cancel()
+
+		prefetch := func(number int) <-chan pageResult {
+			// buffered, so the prefetch never blocks if nobody reads the result
+			result := make(chan pageResult, 1)
+			go func() {
+				page, err := list.fetchPage(ctx, number)
+				result <- pageResult{page: page, err: err}
+			}()
+			return result
+		}
+
+		next := prefetch(list.page)
+		for {
+			current := <-next
+			if current.err != nil {
+				yield("", current.err)
+				return
+			}
+
+			hasNext := current.page.HasNext()
+			if hasNext {
+				next = prefetch(current.page.Number + 1)
+			}
+
+			for _, res := range current.page.Results {
+				if !yield(res.Name, nil) {
+					return
+				}
+			}
+
+			if !hasNext {
+				return
+			}
+		}
+	}
+}
+
+// AllPokemon returns an iterator over fully fetched Pokemon, starting from the page the cursor of the PokemonList
+// points to up to the last one. If a single Pokemon fails to fetch, the error is yielded and the iteration continues.
+func (l *PokemonList) AllPokemon(ctx context.Context) iter.Seq2[*model.Pokemon, error] {
+	return iterateResources(ctx, l.All(ctx), l.resolver.getPokemon)
+}
+
+// AllGenerations returns an iterator over fully fetched Generations, starting from the page the cursor of the
+// GenerationList points to up to the last one. If a single Generation fails to fetch, the error is yielded and the
+// iteration continues.
+func (l *GenerationList) AllGenerations(ctx context.Context) iter.Seq2[*model.Generation, error] {
+	return iterateResources(ctx, l.All(ctx), l.resolver.getGeneration)
+}
+
+type pageResult struct {
+	page *Page
+	err  error
+}
+
+func iterateResources[T any](
+	ctx context.Context,
+	names iter.Seq2[string, error],
+	fetch func(ctx context.Context, id string) (*T, error),
+) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		for name, err := range names {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(fetch(ctx, name)) {
+				return
+			}
+		}
+	}
+}
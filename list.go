@@ -0,0 +1,174 @@
+package pokemon
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"io"
+)
+
+// Page is a single page of a paginated list of named resources, along with metadata about the whole list.
+type Page struct {
+	// The number of this page, starting from 1
+	Number int
+	// The maximum number of results per page
+	Size int
+	// The total number of resources in the list
+	Count int
+	// The total number of pages in the list
+	TotalPages int
+	// The URL of the next page, empty if this is the last page
+	Next string
+	// The URL of the previous page, empty if this is the first page
+	Previous string
+	// The resources on this page
+	Results []model.NamedResource
+}
+
+// Names returns the names of the resources on the page.
+func (p *Page) Names() []string {
+	names := make([]string, len(p.Results))
+	for i, res := range p.Results {
+		names[i] = res.Name
+	}
+
+	return names
+}
+
+// HasNext reports whether there are more pages after this one.
+func (p *Page) HasNext() bool {
+	return p.Count > p.Number*p.Size
+}
+
+// HasPrevious reports whether there are pages before this one.
+func (p *Page) HasPrevious() bool {
+	return p.Number > 1
+}
+
+type listFetcher func(ctx context.Context, limit, offset int) (*model.NamedResourceList, error)
+
+// List contains the pagination state shared by PokemonList and GenerationList.
+//
+// The cursor points to the page which is returned by the next call to Next. Prev goes back to the page before the
+// one last returned, so calling Next and then Prev behaves like the back button of a pager.
+type List struct {
+	fetch    listFetcher
+	first    int
+	page     int
+	pageSize int
+	// the page last returned by Next or Prev, 0 if none
+	current int
+	done    bool
+}
+
+// newList returns a List starting at the page. Pages before the first one start at the first one, and a page size of
+// zero or less is replaced by the default page size of the API, since an empty page never reaches the end of the list.
+func newList(fetch listFetcher, page, pageSize int) List {
+	page = max(page, 1)
+	if pageSize <= 0 {
+		pageSize = defaultListLimit
+	}
+
+	return List{
+		fetch:    fetch,
+		first:    page,
+		page:     page,
+		pageSize: pageSize,
+	}
+}
+
+// GetPage returns the page the cursor points to along with the list metadata, without moving the cursor.
+func (l *List) GetPage(ctx context.Context) (*Page, error) {
+	return l.fetchPage(ctx, l.page)
+}
+
+// NextPage works like Next, but returns the whole Page instead of just the names.
+//
+// The function returns an io.EOF error when the last page is hit.
+func (l *List) NextPage(ctx context.Context) (*Page, error) {
+	if l.done {
+		return nil, io.EOF
+	}
+
+	page, err := l.fetchPage(ctx, l.page)
+	if err != nil {
+		return nil, err
+	}
+
+	l.current = l.page
+	if !page.HasNext() {
+		l.done = true
+	} else {
+		l.page++
+	}
+
+	return page, nil
+}
+
+// Prev returns the names on the page before the one last returned by Next or Prev, and moves the cursor back, so the
+// following call to Next returns the page after it.
+//
+// The function returns an io.EOF error when there is no previous page.
+func (l *List) Prev(ctx context.Context) ([]string, error) {
+	return pageNames(l.PrevPage(ctx))
+}
+
+// PrevPage works like Prev, but returns the whole Page instead of just the names.
+func (l *List) PrevPage(ctx context.Context) (*Page, error) {
+	target := l.current - 1
+	if l.current == 0 {
+		target = l.page - 1
+	}
+
+	if target < 1 {
+		return nil, io.EOF
+	}
+
+	page, err := l.fetchPage(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	l.current = target
+	l.page = target + 1
+	l.done = false
+
+	return page, nil
+}
+
+// Seek moves the cursor to the specified page, so it is returned by the next call to Next.
+func (l *List) Seek(page int) {
+	l.page = max(page, 1)
+	l.current = 0
+	l.done = false
+}
+
+// Reset moves the cursor back to the page the list was created with.
+func (l *List) Reset() {
+	l.Seek(l.first)
+}
+
+func (l *List) fetchPage(ctx context.Context, page int) (*Page, error) {
+	list, err := l.fetch(ctx, l.pageSize, (page-1)*l.pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// newList guarantees a positive page size
+	return &Page{
+		Number:     page,
+		Size:       l.pageSize,
+		Count:      list.Count,
+		TotalPages: (list.Count + l.pageSize - 1) / l.pageSize,
+		Next:       list.Next,
+		Previous:   list.Previous,
+		Results:    list.Results,
+	}, nil
+}
+
+func pageNames(page *Page, err error) ([]string, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Names(), nil
+}
@@ -0,0 +1,43 @@
+package pokemon
+
+import (
+	"strings"
+	"time"
+)
+
+// Metrics receives measurements of the requests and the cache lookups of a Resolver, so the health of the SDK can be
+// monitored. Set it in Config.Metrics, e.g. to a pokemonprom.Collector, or implement it for your own metrics library.
+// Implementations must be safe for concurrent use.
+//
+// Endpoints are URL path templates relative to the base URL e.g., "/pokemon/{id}" for single Pokemon and "/pokemon"
+// for list pages, so they don't multiply with every identifier.
+type Metrics interface {
+	// RequestStarted is called before a request to the endpoint is made.
+	RequestStarted(endpoint string)
+	// RequestFinished is called after a request to the endpoint finished, with the status code of the response, 0 if
+	// there was none, how long it took and the number of bytes of the response body.
+	RequestFinished(endpoint string, status int, duration time.Duration, bytes int)
+	// CacheLookup is called after looking a response of the endpoint up in the cache.
+	CacheLookup(endpoint string, hit bool)
+}
+
+// noopMetrics is used when Config.Metrics isn't set.
+type noopMetrics struct{}
+
+func (noopMetrics) RequestStarted(string)                           {}
+func (noopMetrics) RequestFinished(string, int, time.Duration, int) {}
+func (noopMetrics) CacheLookup(string, bool)                        {}
+
+// endpointOf returns the endpoint template of a URL under the base URL e.g., "/pokemon/{id}" for
+// "https://pokeapi.co/api/v2/pokemon/pikachu".
+func endpointOf(baseURL, url string) string {
+	rel := strings.TrimPrefix(url, baseURL)
+	rel, _, _ = strings.Cut(rel, "?")
+
+	resource, id, _ := strings.Cut(strings.Trim(rel, "/"), "/")
+	if id != "" {
+		return "/" + resource + "/{id}"
+	}
+
+	return "/" + resource
+}
@@ -0,0 +1,51 @@
+package pokemon
+
+import (
+	"net/http"
+)
+
+// Middleware wraps the http.RoundTripper making the requests of a Resolver, e.g. to add headers, log or trace the
+// requests. Middlewares are set in Config.Middleware and applied in order, so the first one sees every request first.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to use a function as an http.RoundTripper, which is handy for writing a Middleware.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// UserAgent returns a Middleware which sets the User-Agent header of every request.
+func UserAgent(userAgent string) Middleware {
+	return SetHeader("User-Agent", userAgent)
+}
+
+// SetHeader returns a Middleware which sets the header of every request e.g., an Authorization header for a proxy in
+// front of the API.
+func SetHeader(key, value string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// a RoundTripper must not modify the request it is given
+			req = req.Clone(req.Context())
+			req.Header.Set(key, value)
+
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// chainMiddleware wraps the transport with the middlewares, the first one being the outermost.
+func chainMiddleware(transport http.RoundTripper, middlewares []Middleware) http.RoundTripper {
+	if len(middlewares) == 0 {
+		return transport
+	}
+
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+
+	return transport
+}
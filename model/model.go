@@ -184,3 +184,18 @@ type PokemonForm struct {
 	// Whether this form requires mega evolution
 	IsMega bool `json:"is_mega"`
 }
+
+// NamedResourceList is a single page of a paginated list of named resources.
+type NamedResourceList struct {
+	// The total number of resources available from this API
+	Count int `json:"count"`
+
+	// The URL for the next page in the list, empty if this is the last page
+	Next string `json:"next"`
+
+	// The URL for the previous page in the list, empty if this is the first page
+	Previous string `json:"previous"`
+
+	// A list of named resources
+	Results []NamedResource `json:"results"`
+}
@@ -0,0 +1,205 @@
+package pokemon
+
+import (
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// Option sets a single setting of a Resolver, see NewResolver and Resolver.Clone. Every option sets the Config field
+// of the same name, so the zero value of an option e.g., WithCacheTTL(0), restores the default.
+type Option func(*Config)
+
+// FromConfig sets every setting from the config, replacing the ones set by earlier options.
+func FromConfig(config Config) Option {
+	return func(c *Config) {
+		*c = config
+	}
+}
+
+// WithBaseURL sets Config.BaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Config) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithClientTimeout sets Config.ClientTimeout.
+func WithClientTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.ClientTimeout = timeout
+	}
+}
+
+// WithHTTPClient sets Config.HTTPClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithMiddleware appends the middleware to Config.Middleware, so it wraps the transport inside any middleware added
+// before.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Config) {
+		c.Middleware = append(c.Middleware, middleware...)
+	}
+}
+
+// WithCacheEnabled sets Config.CacheEnabled.
+func WithCacheEnabled(enabled bool) Option {
+	return func(c *Config) {
+		c.CacheEnabled = enabled
+	}
+}
+
+// WithCacheTTL sets Config.CacheTTL.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *Config) {
+		c.CacheTTL = ttl
+	}
+}
+
+// WithListCacheTTL sets Config.ListCacheTTL.
+func WithListCacheTTL(ttl time.Duration) Option {
+	return func(c *Config) {
+		c.ListCacheTTL = ttl
+	}
+}
+
+// WithStaleWhileRevalidate sets Config.StaleWhileRevalidate.
+func WithStaleWhileRevalidate(d time.Duration) Option {
+	return func(c *Config) {
+		c.StaleWhileRevalidate = d
+	}
+}
+
+// WithStaleIfError sets Config.StaleIfError.
+func WithStaleIfError(d time.Duration) Option {
+	return func(c *Config) {
+		c.StaleIfError = d
+	}
+}
+
+// WithRevalidationWindow sets Config.RevalidationWindow.
+func WithRevalidationWindow(d time.Duration) Option {
+	return func(c *Config) {
+		c.RevalidationWindow = d
+	}
+}
+
+// WithCache sets Config.Cache.
+func WithCache(cache CacheBackend) Option {
+	return func(c *Config) {
+		c.Cache = cache
+	}
+}
+
+// WithCacheDir sets Config.CacheDir.
+func WithCacheDir(dir string) Option {
+	return func(c *Config) {
+		c.CacheDir = dir
+	}
+}
+
+// WithCacheCompression sets Config.CacheCompression.
+func WithCacheCompression(compress bool) Option {
+	return func(c *Config) {
+		c.CacheCompression = compress
+	}
+}
+
+// WithCacheMaxBytes sets Config.CacheMaxBytes.
+func WithCacheMaxBytes(maxBytes int64) Option {
+	return func(c *Config) {
+		c.CacheMaxBytes = maxBytes
+	}
+}
+
+// WithCacheEviction sets Config.CacheEviction.
+func WithCacheEviction(policy EvictionPolicy) Option {
+	return func(c *Config) {
+		c.CacheEviction = policy
+	}
+}
+
+// WithSnapshot sets Config.Snapshot.
+func WithSnapshot(snapshot *Snapshot) Option {
+	return func(c *Config) {
+		c.Snapshot = snapshot
+	}
+}
+
+// WithAPIDataDir sets Config.APIDataDir.
+func WithAPIDataDir(dir string) Option {
+	return func(c *Config) {
+		c.APIDataDir = dir
+	}
+}
+
+// WithFixtures sets Config.FixturesDir and Config.FixturesMode.
+func WithFixtures(dir string, mode FixturesMode) Option {
+	return func(c *Config) {
+		c.FixturesDir = dir
+		c.FixturesMode = mode
+	}
+}
+
+// WithLogger sets Config.Logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Config) {
+		c.Logger = logger
+	}
+}
+
+// WithTracerProvider sets Config.TracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Config) {
+		c.TracerProvider = provider
+	}
+}
+
+// WithMetrics sets Config.Metrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *Config) {
+		c.Metrics = metrics
+	}
+}
+
+// WithBatchWorkers sets Config.BatchWorkers.
+func WithBatchWorkers(workers int) Option {
+	return func(c *Config) {
+		c.BatchWorkers = workers
+	}
+}
+
+// sameCacheSettings reports whether two configs result in the same cache backend, so a clone can share it.
+func sameCacheSettings(a, b Config) bool {
+	if a.Snapshot != nil || b.Snapshot != nil {
+		// the snapshot cache resolves URLs against the base URL
+		return a.Snapshot == b.Snapshot && a.BaseURL == b.BaseURL
+	}
+
+	return sameBackend(a.Cache, b.Cache) &&
+		a.CacheDir == b.CacheDir &&
+		a.CacheEnabled == b.CacheEnabled &&
+		a.CacheCompression == b.CacheCompression &&
+		a.CacheMaxBytes == b.CacheMaxBytes &&
+		a.CacheEviction == b.CacheEviction &&
+		// the default in-memory cache is created with the TTL
+		a.CacheTTL == b.CacheTTL
+}
+
+// sameBackend compares custom cache backends without panicking on ones which aren't comparable e.g., a func type.
+func sameBackend(a, b CacheBackend) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+
+	return a == b
+}
@@ -1,10 +1,11 @@
 package pokemon
 
 import (
+	"cmp"
 	"context"
 	"github.com/boyski33/pokemon-sdk/v2/model"
-	"io"
 	"net/http"
+	"slices"
 )
 
 // Pokemon is a helper type with a reference to the Resolver making the requests.
@@ -29,13 +30,6 @@ func (p *Pokemon) GetWithContext(ctx context.Context) (*model.Pokemon, error) {
 	return data, nil
 }
 
-type List struct {
-	page     int
-	pageSize int
-	limit    int
-	done     bool
-}
-
 // PokemonList is a helper type with a reference to the Resolver making the requests. It is used to make paginated
 // requests to the Pokemon API.
 type PokemonList struct {
@@ -51,12 +45,7 @@ func (l *PokemonList) Get() ([]string, error) {
 // GetWithContext returns a specific page based on the PokemonList. You can use Next if you need more results. You can pass a
 // context.Context if you want more granular control over the lifecycle of the request i.e., setting timeouts.
 func (l *PokemonList) GetWithContext(ctx context.Context) ([]string, error) {
-	names, _, err := l.resolver.getPokemonNames(ctx, l.pageSize, (l.page-1)*l.pageSize)
-	if err != nil {
-		return nil, err
-	}
-
-	return names, nil
+	return pageNames(l.GetPage(ctx))
 }
 
 // The Next method allows for cursor pagination. Defined by the pageSize field in the PokemonList object, Next fetches the next
@@ -65,22 +54,7 @@ func (l *PokemonList) GetWithContext(ctx context.Context) ([]string, error) {
 //
 // The function returns an io.EOF error when the last page is hit.
 func (l *PokemonList) Next(ctx context.Context) ([]string, error) {
-	if l.done {
-		return nil, io.EOF
-	}
-
-	names, hasMore, err := l.resolver.getPokemonNames(ctx, l.pageSize, (l.page-1)*l.pageSize)
-	if err != nil {
-		return nil, err
-	}
-
-	if !hasMore {
-		l.done = true
-	} else {
-		l.page++
-	}
-
-	return names, nil
+	return pageNames(l.NextPage(ctx))
 }
 
 // Generation is a helper type with a reference to the Resolver making the requests
@@ -119,12 +93,7 @@ func (l *GenerationList) Get() ([]string, error) {
 // You can pass a context.Context if you want more granular control over the lifecycle of the request
 // i.e., setting timeouts.
 func (l *GenerationList) GetWithContext(ctx context.Context) ([]string, error) {
-	names, _, err := l.resolver.getGenerationNames(ctx, l.pageSize, (l.page-1)*l.pageSize)
-	if err != nil {
-		return nil, err
-	}
-
-	return names, nil
+	return pageNames(l.GetPage(ctx))
 }
 
 // The Next method allows for cursor pagination. Defined by the pageSize field in the GenerationList object,
@@ -133,53 +102,115 @@ func (l *GenerationList) GetWithContext(ctx context.Context) ([]string, error) {
 //
 // The function returns an io.EOF error when the last page is hit.
 func (l *GenerationList) Next(ctx context.Context) ([]string, error) {
-	if l.done {
-		return nil, io.EOF
-	}
-
-	names, hasMore, err := l.resolver.getGenerationNames(ctx, l.pageSize, (l.page-1)*l.pageSize)
-	if err != nil {
-		return nil, err
-	}
-
-	if !hasMore {
-		l.done = true
-	} else {
-		l.page++
-	}
-
-	return names, nil
+	return pageNames(l.NextPage(ctx))
 }
 
 // Resolver is the main type you will use when interacting with the SDK. It creates helper objects, such as Pokemon and
 // Generation, and it contains the HTTP client used for fetching data from the remote Pokemon API.
+//
+// Apart from the deprecated WithConfig, a Resolver can't be changed after it is created, so it is safe to share between
+// goroutines. Use Resolver.Clone to get a Resolver with different settings.
 type Resolver struct {
-	client *client
+	// the settings the Resolver was created with
+	config       Config
+	client       *client
+	batchWorkers int
 }
 
-// NewResolver returns a Resolver with a default client and the cache disabled. If you want to change the configuration,
-// you can use the Resolver.WithConfig function.
-func NewResolver() *Resolver {
-	return &Resolver{
-		client: newClient(defaultBaseURL, &http.Client{Timeout: defaultClientTimeout}, nil),
+// NewResolver returns a Resolver with the options applied. Without options, it uses the public API with the default
+// timeout and the cache disabled.
+//
+//	resolver := pokemon.NewResolver(
+//		pokemon.WithCacheEnabled(true),
+//		pokemon.WithCacheTTL(time.Hour),
+//	)
+func NewResolver(opts ...Option) *Resolver {
+	var config Config
+	for _, opt := range opts {
+		opt(&config)
 	}
+
+	return newResolver(config, nil)
 }
 
-// WithConfig accepts a Config object and sets the specified configuration to the Resolver.
+// WithConfig sets the specified configuration to the Resolver and returns it, replacing all of its settings. Unlike
+// the rest of the Resolver, it must not be called while the Resolver is in use by other goroutines.
+//
+// Deprecated: Resolvers should be configured when they are created, with NewResolver(FromConfig(config)), or copied
+// with different settings with Resolver.Clone.
 func (r *Resolver) WithConfig(config Config) *Resolver {
-	if config.BaseURL != "" {
-		r.client.baseURL = config.BaseURL
+	*r = *newResolver(config, nil)
+	return r
+}
+
+// Clone returns a new Resolver with the settings of this one and the options applied on top, e.g. for a call which
+// needs a shorter timeout. Unless the options change the cache settings, the clone shares the cache, and its
+// statistics, with this Resolver.
+func (r *Resolver) Clone(opts ...Option) *Resolver {
+	config := r.config
+	// so appending middleware to the clone doesn't change the slice of this Resolver
+	config.Middleware = slices.Clone(config.Middleware)
+	for _, opt := range opts {
+		opt(&config)
 	}
 
+	return newResolver(config, r)
+}
+
+// newResolver creates a Resolver from the config. If parent is not nil and the config has the same cache settings, the
+// cache is shared with the parent.
+func newResolver(config Config, parent *Resolver) *Resolver {
+	baseURL := cmp.Or(config.BaseURL, defaultBaseURL)
+
+	httpClient := &http.Client{Timeout: defaultClientTimeout}
+	if config.HTTPClient != nil {
+		// copied once, so changes to the client of the caller don't leak into the Resolver or its clones
+		owned := *config.HTTPClient
+		config.HTTPClient = &owned
+
+		// copied again, since the Resolver changes the timeout and the transport of its client
+		c := owned
+		httpClient = &c
+	}
 	if config.ClientTimeout != 0 {
-		r.client.httpClient.Timeout = config.ClientTimeout
+		httpClient.Timeout = config.ClientTimeout
 	}
+	httpClient.Transport = transportFromConfig(config, baseURL, httpClient.Transport)
 
-	if config.CacheEnabled {
-		r.client.cache = NewCache(config.CacheTTL)
+	c := newClient(baseURL, httpClient, nil)
+
+	if parent != nil && sameCacheSettings(config, parent.config) {
+		c.cache = parent.client.cache
+		c.counters = parent.client.counters
+	} else {
+		c.cache = cacheFromConfig(config)
 	}
 
-	return r
+	if c.cache != nil {
+		c.cacheTTL = config.CacheTTL
+		c.listCacheTTL = cmp.Or(config.ListCacheTTL, config.CacheTTL)
+		c.staleWhileRevalidate = config.StaleWhileRevalidate
+		c.staleIfError = config.StaleIfError
+		c.revalidationWindow = cmp.Or(config.RevalidationWindow, defaultRevalidationWindow)
+	}
+
+	if config.Logger != nil {
+		c.logger = config.Logger
+	}
+
+	if config.TracerProvider != nil {
+		c.tracer = config.TracerProvider.Tracer(tracerName)
+	}
+
+	if config.Metrics != nil {
+		c.metrics = config.Metrics
+	}
+
+	return &Resolver{
+		config:       config,
+		client:       c,
+		batchWorkers: cmp.Or(config.BatchWorkers, defaultBatchWorkers),
+	}
 }
 
 // Pokemon returns a new Pokemon object with the specified identifier (ID or name) and a reference to the Resolver.
@@ -194,13 +225,17 @@ func (r *Resolver) Pokemon(id string) *Pokemon {
 func (r *Resolver) PokemonList(page, pageSize int) *PokemonList {
 	return &PokemonList{
 		resolver: r,
-		List: List{
-			page:     page,
-			pageSize: pageSize,
-		},
+		List:     newList(r.getPokemonList, page, pageSize),
 	}
 }
 
+// AllPokemonNames returns the names and URLs of every Pokemon. Instead of paginating, it makes one small request to
+// find out the total number of Pokemon and then fetches all of them in a single request. The result is cached if the
+// cache is enabled.
+func (r *Resolver) AllPokemonNames(ctx context.Context) ([]model.NamedResource, error) {
+	return r.client.GetAllPokemonNames(ctx)
+}
+
 // Generation returns a new Generation object with the specified
 // identifier (ID or name) and a reference to the Resolver.
 func (r *Resolver) Generation(id string) *Generation {
@@ -214,25 +249,27 @@ func (r *Resolver) Generation(id string) *Generation {
 func (r *Resolver) GenerationList(page, pageSize int) *GenerationList {
 	return &GenerationList{
 		resolver: r,
-		List: List{
-			page:     page,
-			pageSize: pageSize,
-		},
+		List:     newList(r.getGenerationList, page, pageSize),
 	}
 }
 
+// AllGenerationNames returns the names and URLs of every Generation. See AllPokemonNames.
+func (r *Resolver) AllGenerationNames(ctx context.Context) ([]model.NamedResource, error) {
+	return r.client.GetAllGenerationNames(ctx)
+}
+
 func (r *Resolver) getPokemon(ctx context.Context, id string) (*model.Pokemon, error) {
 	return r.client.GetPokemonByIDOrName(ctx, id)
 }
 
-func (r *Resolver) getPokemonNames(ctx context.Context, limit, offset int) ([]string, bool, error) {
-	return r.client.GetPokemonNamesList(ctx, limit, offset)
+func (r *Resolver) getPokemonList(ctx context.Context, limit, offset int) (*model.NamedResourceList, error) {
+	return r.client.GetPokemonList(ctx, limit, offset)
 }
 
 func (r *Resolver) getGeneration(ctx context.Context, id string) (*model.Generation, error) {
 	return r.client.GetGenerationByIDOrName(ctx, id)
 }
 
-func (r *Resolver) getGenerationNames(ctx context.Context, limit, offset int) ([]string, bool, error) {
-	return r.client.GetGenerationNamesList(ctx, limit, offset)
+func (r *Resolver) getGenerationList(ctx context.Context, limit, offset int) (*model.NamedResourceList, error) {
+	return r.client.GetGenerationList(ctx, limit, offset)
 }
@@ -0,0 +1,160 @@
+// Package pokemonprom measures the requests and the cache lookups of a pokemon.Resolver with Prometheus.
+//
+// The Collector is registered like any other collector and served by your existing metrics handler:
+//
+//	collector := pokemonprom.NewCollector(pokemonprom.Options{})
+//	prometheus.MustRegister(collector)
+//
+//	resolver := pokemon.NewResolver(pokemon.WithMetrics(collector))
+package pokemonprom
+
+import (
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Options configures a Collector.
+type Options struct {
+	// The prefix of the metric names, "pokemon_sdk" by default
+	Namespace string
+	// The upper bounds of the request latency histogram buckets in seconds, from 5ms to 10s by default
+	Buckets []float64
+	// Labels added to every metric e.g., to tell several Resolvers apart
+	ConstLabels prometheus.Labels
+}
+
+// Collector is a pokemon.Metrics implementation and a prometheus.Collector. The following metrics are collected, with
+// the namespace as prefix:
+//
+//   - requests_total: the number of requests, by endpoint and status code ("error" if there was no response)
+//   - request_duration_seconds: a histogram of the request latency, by endpoint
+//   - requests_in_flight: the number of requests in progress
+//   - response_bytes_total: the number of bytes downloaded, by endpoint
+//   - cache_lookups_total: the number of cache lookups, by endpoint and result ("hit" or "miss")
+//   - cache_hit_ratio: the ratio of cache lookups which found a response
+type Collector struct {
+	requests  *prometheus.CounterVec
+	durations *prometheus.HistogramVec
+	inFlight  prometheus.Gauge
+	bytes     *prometheus.CounterVec
+	lookups   *prometheus.CounterVec
+	hitRatio  prometheus.GaugeFunc
+
+	hits, total atomic.Uint64
+}
+
+var (
+	_ pokemon.Metrics      = (*Collector)(nil)
+	_ prometheus.Collector = (*Collector)(nil)
+)
+
+// NewCollector returns a Collector without any measurements. It has to be registered to be exposed.
+func NewCollector(opts Options) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "pokemon_sdk"
+	}
+	if len(opts.Buckets) == 0 {
+		opts.Buckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	}
+
+	c := &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "requests_total",
+			Help:        "The number of requests made to the API.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint", "status"}),
+		durations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "The latency of the requests made to the API.",
+			ConstLabels: opts.ConstLabels,
+			Buckets:     opts.Buckets,
+		}, []string{"endpoint"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace:   opts.Namespace,
+			Name:        "requests_in_flight",
+			Help:        "The number of requests to the API in progress.",
+			ConstLabels: opts.ConstLabels,
+		}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "response_bytes_total",
+			Help:        "The number of bytes downloaded from the API.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint"}),
+		lookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "cache_lookups_total",
+			Help:        "The number of cache lookups.",
+			ConstLabels: opts.ConstLabels,
+		}, []string{"endpoint", "result"}),
+	}
+	c.hitRatio = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   opts.Namespace,
+		Name:        "cache_hit_ratio",
+		Help:        "The ratio of cache lookups which found a response.",
+		ConstLabels: opts.ConstLabels,
+	}, c.ratio)
+
+	return c
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors() {
+		collector.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors() {
+		collector.Collect(ch)
+	}
+}
+
+// RequestStarted implements pokemon.Metrics.
+func (c *Collector) RequestStarted(string) {
+	c.inFlight.Inc()
+}
+
+// RequestFinished implements pokemon.Metrics.
+func (c *Collector) RequestFinished(endpoint string, status int, duration time.Duration, bytes int) {
+	c.inFlight.Dec()
+
+	statusLabel := "error"
+	if status != 0 {
+		statusLabel = strconv.Itoa(status)
+	}
+	c.requests.WithLabelValues(endpoint, statusLabel).Inc()
+	c.durations.WithLabelValues(endpoint).Observe(duration.Seconds())
+	c.bytes.WithLabelValues(endpoint).Add(float64(bytes))
+}
+
+// CacheLookup implements pokemon.Metrics.
+func (c *Collector) CacheLookup(endpoint string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+		c.hits.Add(1)
+	}
+	c.total.Add(1)
+	c.lookups.WithLabelValues(endpoint, result).Inc()
+}
+
+func (c *Collector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{c.requests, c.durations, c.inFlight, c.bytes, c.lookups, c.hitRatio}
+}
+
+func (c *Collector) ratio() float64 {
+	total := c.total.Load()
+	if total == 0 {
+		return 0
+	}
+
+	return float64(c.hits.Load()) / float64(total)
+}
@@ -0,0 +1,181 @@
+package pokemontest
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Fake is an in-memory implementation of pokemon.API for unit tests, which doesn't involve HTTP at all. Resources are
+// looked up by ID or by name, like the Resolver does, and listed in ID order. It is safe for concurrent use.
+//
+//	fake := pokemontest.NewFake()
+//	fake.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+//
+//	var api pokemon.API = fake
+type Fake struct {
+	mu          sync.Mutex
+	pokemon     []*model.Pokemon
+	generations []*model.Generation
+	err         error
+}
+
+var _ pokemon.API = (*Fake)(nil)
+
+// NewFake returns a Fake without any resources.
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+// AddPokemon adds the Pokemon, replacing any with the same ID.
+func (f *Fake) AddPokemon(list ...*model.Pokemon) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, p := range list {
+		f.pokemon = addByID(f.pokemon, p, func(p *model.Pokemon) int { return p.ID })
+	}
+}
+
+// AddGeneration adds the Generations, replacing any with the same ID.
+func (f *Fake) AddGeneration(generations ...*model.Generation) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, g := range generations {
+		f.generations = addByID(f.generations, g, func(g *model.Generation) int { return g.ID })
+	}
+}
+
+// SetError makes every call fail with err, e.g. to test how your code handles the API being down. Passing nil makes
+// the calls succeed again.
+func (f *Fake) SetError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.err = err
+}
+
+// GetPokemon returns the Pokemon with the identifier, or model.ErrNotFound.
+func (f *Fake) GetPokemon(_ context.Context, idOrName string) (*model.Pokemon, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return find(f.pokemon, f.err, "pokemon", idOrName, func(p *model.Pokemon) (int, string) { return p.ID, p.Name })
+}
+
+// ListPokemon returns a single page of the Pokemon, ordered by ID.
+func (f *Fake) ListPokemon(_ context.Context, page, pageSize int) (*pokemon.Page, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return listPage(f.pokemon, f.err, "pokemon", page, pageSize, func(p *model.Pokemon) (int, string) { return p.ID, p.Name })
+}
+
+// AllPokemonNames returns every Pokemon, ordered by ID.
+func (f *Fake) AllPokemonNames(ctx context.Context) ([]model.NamedResource, error) {
+	f.mu.Lock()
+	count := len(f.pokemon)
+	f.mu.Unlock()
+
+	page, err := f.ListPokemon(ctx, 1, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Results, nil
+}
+
+// GetGeneration returns the Generation with the identifier, or model.ErrNotFound.
+func (f *Fake) GetGeneration(_ context.Context, idOrName string) (*model.Generation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return find(f.generations, f.err, "generation", idOrName, func(g *model.Generation) (int, string) { return g.ID, g.Name })
+}
+
+// ListGenerations returns a single page of the Generations, ordered by ID.
+func (f *Fake) ListGenerations(_ context.Context, page, pageSize int) (*pokemon.Page, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return listPage(f.generations, f.err, "generation", page, pageSize, func(g *model.Generation) (int, string) { return g.ID, g.Name })
+}
+
+// AllGenerationNames returns every Generation, ordered by ID.
+func (f *Fake) AllGenerationNames(ctx context.Context) ([]model.NamedResource, error) {
+	f.mu.Lock()
+	count := len(f.generations)
+	f.mu.Unlock()
+
+	page, err := f.ListGenerations(ctx, 1, count)
+	if err != nil {
+		return nil, err
+	}
+
+	return page.Results, nil
+}
+
+// addByID inserts v into the resources ordered by ID, replacing the one with the same ID.
+func addByID[T any](resources []T, v T, id func(T) int) []T {
+	resources = slices.DeleteFunc(resources, func(r T) bool { return id(r) == id(v) })
+	i, _ := slices.BinarySearchFunc(resources, id(v), func(r T, target int) int { return cmp.Compare(id(r), target) })
+
+	return slices.Insert(resources, i, v)
+}
+
+func find[T any](resources []T, err error, resourceType, idOrName string, identify func(T) (int, string)) (T, error) {
+	var zero T
+	if err != nil {
+		return zero, err
+	}
+
+	idOrName = strings.ToLower(strings.TrimSpace(idOrName))
+	for _, r := range resources {
+		if id, name := identify(r); strconv.Itoa(id) == idOrName || name == idOrName {
+			return r, nil
+		}
+	}
+
+	return zero, fmt.Errorf("failed to fetch %s: %w", resourceType, model.ErrNotFound)
+}
+
+func listPage[T any](resources []T, err error, resourceType string, page, pageSize int, identify func(T) (int, string)) (*pokemon.Page, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	page, pageSize = max(page, 1), max(pageSize, 0)
+	offset := (page - 1) * pageSize
+
+	result := &pokemon.Page{
+		Number:  page,
+		Size:    pageSize,
+		Count:   len(resources),
+		Results: []model.NamedResource{},
+	}
+	if pageSize > 0 {
+		result.TotalPages = (len(resources) + pageSize - 1) / pageSize
+	}
+	for _, r := range resources[min(offset, len(resources)):min(offset+pageSize, len(resources))] {
+		id, name := identify(r)
+		result.Results = append(result.Results, model.NamedResource{
+			Name: name,
+			URL:  fmt.Sprintf("fake://%s/%d/", resourceType, id),
+		})
+	}
+	if result.HasNext() {
+		result.Next = fmt.Sprintf("fake://%s?offset=%d&limit=%d", resourceType, offset+pageSize, pageSize)
+	}
+	if result.HasPrevious() {
+		result.Previous = fmt.Sprintf("fake://%s?offset=%d&limit=%d", resourceType, max(offset-pageSize, 0), pageSize)
+	}
+
+	return result, nil
+}
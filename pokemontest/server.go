@@ -0,0 +1,289 @@
+// Package pokemontest provides a fake Pokemon API for testing code which uses the SDK, without writing HTTP handlers.
+//
+// The fake API is seeded with resources, either as Go structs or as JSON, and serves them like the public API does,
+// including list pages and "404 Not Found" for unknown resources. It can also slow responses down, fail a share of
+// them, and count the requests it receives:
+//
+//	server := pokemontest.NewServer(t)
+//	server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+//
+//	resolver := server.Resolver()
+//	p, err := resolver.Pokemon("pikachu").Get()
+package pokemontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// defaultListLimit is the page size of the public API when the limit query parameter is missing.
+const defaultListLimit = 20
+
+// Server is a fake Pokemon API running on a local httptest.Server. It is safe for concurrent use, so it can be seeded
+// and configured while requests are being served.
+type Server struct {
+	// The base URL of the fake API, to be used as Config.BaseURL
+	URL string
+
+	server *httptest.Server
+
+	mu sync.Mutex
+	// resources by type, ordered by ID
+	resources map[string][]resource
+	latency   time.Duration
+	errorRate float64
+	// the status code of the injected errors
+	errorStatus int
+	// the number of requests by URL path
+	calls map[string]int
+}
+
+// resource is a single resource served by the Server.
+type resource struct {
+	id   int
+	name string
+	body []byte
+}
+
+// NewServer starts a fake API without any resources. It is closed when the test finishes.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	s := &Server{
+		// the endpoints of the SDK exist even before any resources are added
+		resources:   map[string][]resource{"pokemon": nil, "generation": nil},
+		errorStatus: http.StatusInternalServerError,
+		calls:       make(map[string]int),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.server.URL
+	tb.Cleanup(s.Close)
+
+	return s
+}
+
+// Close shuts the fake API down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Resolver returns a new Resolver using the fake API, with the config applied on top. The BaseURL of the config is
+// ignored.
+func (s *Server) Resolver(config ...pokemon.Config) *pokemon.Resolver {
+	var c pokemon.Config
+	if len(config) > 0 {
+		c = config[0]
+	}
+	c.BaseURL = s.URL
+
+	return pokemon.NewResolver(pokemon.FromConfig(c))
+}
+
+// AddPokemon adds the Pokemon to the fake API, replacing any with the same ID.
+func (s *Server) AddPokemon(list ...*model.Pokemon) {
+	for _, p := range list {
+		s.mustAdd("pokemon", p.ID, p.Name, p)
+	}
+}
+
+// AddGeneration adds the Generations to the fake API, replacing any with the same ID.
+func (s *Server) AddGeneration(generations ...*model.Generation) {
+	for _, g := range generations {
+		s.mustAdd("generation", g.ID, g.Name, g)
+	}
+}
+
+// AddJSON adds a resource of the given type e.g., "pokemon", from its JSON representation, as returned by the public
+// API. The JSON must have the "id" and "name" fields.
+func (s *Server) AddJSON(resourceType string, data []byte) error {
+	var identity struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &identity); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", resourceType, err)
+	}
+	if identity.ID == 0 || identity.Name == "" {
+		return fmt.Errorf("%s is missing an id or a name", resourceType)
+	}
+
+	s.add(resourceType, resource{id: identity.ID, name: identity.Name, body: data})
+	return nil
+}
+
+// AddJSONFile adds a resource of the given type from a JSON file e.g., a response saved from the public API. See
+// AddJSON.
+func (s *Server) AddJSONFile(resourceType, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return s.AddJSON(resourceType, data)
+}
+
+// SetLatency delays every response by d.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.latency = d
+}
+
+// SetErrorRate makes the given share of responses, between 0 and 1, fail with the status code instead of being
+// served. A status code of 0 means "500 Internal Server Error".
+func (s *Server) SetErrorRate(rate float64, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	s.errorRate = rate
+	s.errorStatus = status
+}
+
+// Calls returns the number of requests for the URL path e.g., "/pokemon/pikachu" or "/pokemon" for the list pages,
+// including the ones which failed.
+func (s *Server) Calls(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[path]
+}
+
+// TotalCalls returns the number of requests the fake API received.
+func (s *Server) TotalCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var total int
+	for _, n := range s.calls {
+		total += n
+	}
+
+	return total
+}
+
+// ResetCalls sets the request counters back to zero.
+func (s *Server) ResetCalls() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.calls)
+}
+
+// mustAdd adds a resource given as a Go struct. The model types always marshal, so failing to is a programming error.
+func (s *Server) mustAdd(resourceType string, id int, name string, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("pokemontest: failed to marshal %s: %v", resourceType, err))
+	}
+
+	s.add(resourceType, resource{id: id, name: name, body: body})
+}
+
+func (s *Server) add(resourceType string, res resource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resources[resourceType] = addByID(s.resources[resourceType], res, func(r resource) int { return r.id })
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	s.mu.Lock()
+	s.calls[path]++
+	latency, fail, errorStatus := s.latency, rand.Float64() < s.errorRate, s.errorStatus
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if fail {
+		http.Error(w, http.StatusText(errorStatus), errorStatus)
+		return
+	}
+
+	resourceType, idOrName, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if idOrName == "" {
+		s.writeList(w, r, resourceType)
+		return
+	}
+
+	s.mu.Lock()
+	i := slices.IndexFunc(s.resources[resourceType], func(res resource) bool {
+		return strconv.Itoa(res.id) == idOrName || res.name == idOrName
+	})
+	var body []byte
+	if i >= 0 {
+		body = s.resources[resourceType][i].body
+	}
+	s.mu.Unlock()
+
+	if body == nil {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// writeList writes a page of the resources of the type, based on the limit and offset query parameters of the request.
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, resourceType string) {
+	limit, offset := defaultListLimit, 0
+	if param := r.URL.Query().Get("limit"); param != "" {
+		limit, _ = strconv.Atoi(param)
+	}
+	if param := r.URL.Query().Get("offset"); param != "" {
+		offset, _ = strconv.Atoi(param)
+	}
+	limit, offset = max(limit, 0), max(offset, 0)
+
+	s.mu.Lock()
+	resources, ok := s.resources[resourceType]
+	page := model.NamedResourceList{Count: len(resources), Results: []model.NamedResource{}}
+	for _, res := range resources[min(offset, len(resources)):min(offset+limit, len(resources))] {
+		page.Results = append(page.Results, model.NamedResource{
+			Name: res.name,
+			URL:  fmt.Sprintf("%s/%s/%d/", s.URL, resourceType, res.id),
+		})
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
+	if offset+limit < page.Count {
+		page.Next = fmt.Sprintf("%s/%s?offset=%d&limit=%d", s.URL, resourceType, offset+limit, limit)
+	}
+	if offset > 0 {
+		page.Previous = fmt.Sprintf("%s/%s?offset=%d&limit=%d", s.URL, resourceType, max(offset-limit, 0), limit)
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(page)
+}
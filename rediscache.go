@@ -0,0 +1,408 @@
+package pokemon
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRedisKeyPrefix    = "pokemon-sdk:"
+	defaultRedisTimeout      = 1 * time.Second
+	defaultRedisRetryBackoff = 5 * time.Second
+	defaultRedisPoolSize     = 4
+)
+
+// RedisCacheOptions contains the settings of a RedisCache.
+type RedisCacheOptions struct {
+	// The address of the Redis server e.g., localhost:6379
+	Addr string
+	// The password used to authenticate, if required by the server
+	Password string
+	// The database selected after connecting
+	DB int
+	// The prefix added to every key, so the cache can share a Redis server with other applications. Defaults to
+	// "pokemon-sdk:"
+	KeyPrefix string
+	// The timeout for connecting and for every command. Defaults to 1 second
+	Timeout time.Duration
+	// How long the server is skipped after it fails, so requests aren't slowed down while it is unavailable. Defaults
+	// to 5 seconds
+	RetryBackoff time.Duration
+	// The maximum number of idle connections kept open. Defaults to 4
+	PoolSize int
+}
+
+// RedisCache is a CacheBackend storing responses in Redis, so the cache can be shared by many instances of an
+// application. It speaks the Redis protocol directly and doesn't require any additional dependencies.
+//
+// If the server is unavailable, the cache reports an error for every operation for the duration of RetryBackoff,
+// without trying to connect. The Resolver treats those errors as cache misses and fetches from the API instead.
+type RedisCache struct {
+	opts RedisCacheOptions
+
+	mu        sync.Mutex
+	idle      []*redisConn
+	downUntil time.Time
+}
+
+// NewRedisCache creates a new RedisCache with the specified options. It doesn't connect to the server until the cache
+// is first used.
+func NewRedisCache(opts RedisCacheOptions) *RedisCache {
+	if opts.KeyPrefix == "" {
+		opts.KeyPrefix = defaultRedisKeyPrefix
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultRedisTimeout
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = defaultRedisRetryBackoff
+	}
+	if opts.PoolSize <= 0 {
+		opts.PoolSize = defaultRedisPoolSize
+	}
+
+	return &RedisCache{opts: opts}
+}
+
+// Get implements CacheBackend.
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := c.do(ctx, "GET", c.opts.KeyPrefix+key)
+	if err != nil {
+		return nil, err
+	}
+
+	if reply == nil {
+		return nil, ErrCacheMiss
+	}
+
+	data, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected redis reply %v", reply)
+	}
+
+	return data, nil
+}
+
+// Set implements CacheBackend.
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []any{"SET", c.opts.KeyPrefix + key, value}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(max(ttl.Milliseconds(), 1), 10))
+	}
+
+	_, err := c.do(ctx, args...)
+	return err
+}
+
+// Delete implements CacheBackend.
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	_, err := c.do(ctx, "DEL", c.opts.KeyPrefix+key)
+	return err
+}
+
+// Keys implements CacheInspector. It scans the keys with the KeyPrefix, so it doesn't block the server.
+func (c *RedisCache) Keys(ctx context.Context) ([]string, error) {
+	pattern := redisGlobEscaper.Replace(c.opts.KeyPrefix) + "*"
+
+	var keys []string
+	cursor := "0"
+	for {
+		reply, err := c.do(ctx, "SCAN", cursor, "MATCH", pattern, "COUNT", "100")
+		if err != nil {
+			return nil, err
+		}
+
+		page, ok := reply.([]any)
+		if !ok || len(page) != 2 {
+			return nil, fmt.Errorf("unexpected redis reply %v", reply)
+		}
+
+		next, _ := page[0].([]byte)
+		items, _ := page[1].([]any)
+		for _, item := range items {
+			if key, ok := item.([]byte); ok {
+				keys = append(keys, strings.TrimPrefix(string(key), c.opts.KeyPrefix))
+			}
+		}
+
+		cursor = string(next)
+		if cursor == "0" || cursor == "" {
+			return keys, nil
+		}
+	}
+}
+
+// Flush implements CacheInspector. Only the keys with the KeyPrefix are removed.
+func (c *RedisCache) Flush(ctx context.Context) error {
+	keys, err := c.Keys(ctx)
+	if err != nil {
+		return err
+	}
+
+	for batch := range slices.Chunk(keys, 100) {
+		args := []any{"DEL"}
+		for _, key := range batch {
+			args = append(args, c.opts.KeyPrefix+key)
+		}
+
+		if _, err := c.do(ctx, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Usage implements CacheInspector. Only the number of items is reported, since Redis manages its own memory and
+// evictions.
+func (c *RedisCache) Usage(ctx context.Context) (CacheUsage, error) {
+	keys, err := c.Keys(ctx)
+	if err != nil {
+		return CacheUsage{}, err
+	}
+
+	return CacheUsage{Items: len(keys)}, nil
+}
+
+var redisGlobEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// do sends a command to the server and returns its reply. The reply is nil, []byte, string, int64 or []any.
+func (c *RedisCache) do(ctx context.Context, args ...any) (any, error) {
+	conn, err := c.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, c.opts.Timeout, args...)
+
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		// the connection is in an unknown state
+		conn.close()
+		if ctx.Err() != nil {
+			// the caller gave up, which says nothing about the server
+			return nil, ctx.Err()
+		}
+		c.markDown()
+		return nil, fmt.Errorf("redis unavailable: %w", err)
+	}
+
+	c.release(conn)
+	return reply, err
+}
+
+func (c *RedisCache) conn(ctx context.Context) (*redisConn, error) {
+	c.mu.Lock()
+	if time.Now().Before(c.downUntil) {
+		c.mu.Unlock()
+		return nil, errors.New("redis unavailable")
+	}
+
+	if n := len(c.idle); n > 0 {
+		conn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return conn, nil
+	}
+	c.mu.Unlock()
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		c.markDown()
+		return nil, fmt.Errorf("redis unavailable: %w", err)
+	}
+
+	return conn, nil
+}
+
+func (c *RedisCache) dial(ctx context.Context) (*redisConn, error) {
+	dialer := net.Dialer{Timeout: c.opts.Timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.opts.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &redisConn{conn: netConn, r: bufio.NewReader(netConn)}
+
+	if c.opts.Password != "" {
+		if _, err := conn.do(ctx, c.opts.Timeout, "AUTH", c.opts.Password); err != nil {
+			conn.close()
+			return nil, err
+		}
+	}
+
+	if c.opts.DB != 0 {
+		if _, err := conn.do(ctx, c.opts.Timeout, "SELECT", strconv.Itoa(c.opts.DB)); err != nil {
+			conn.close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (c *RedisCache) release(conn *redisConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.idle) >= c.opts.PoolSize {
+		conn.close()
+		return
+	}
+	c.idle = append(c.idle, conn)
+}
+
+func (c *RedisCache) markDown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.downUntil = time.Now().Add(c.opts.RetryBackoff)
+	for _, conn := range c.idle {
+		conn.close()
+	}
+	c.idle = nil
+}
+
+// redisError is an error reply sent by the server. It doesn't affect the state of the connection.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// redisConn is a single connection speaking RESP, the Redis serialization protocol.
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func (c *redisConn) close() {
+	_ = c.conn.Close()
+}
+
+// do sends a command and reads its reply. Cancelling ctx interrupts the command, leaving the connection in an unknown
+// state, so it must be closed after any error other than a redisError.
+func (c *redisConn) do(ctx context.Context, timeout time.Duration, args ...any) (any, error) {
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	// a deadline in the past unblocks the pending read or write
+	stop := context.AfterFunc(ctx, func() { _ = c.conn.SetDeadline(time.Unix(1, 0)) })
+	reply, err := c.roundTrip(args)
+	if !stop() {
+		return nil, ctx.Err()
+	}
+
+	return reply, err
+}
+
+func (c *redisConn) roundTrip(args []any) (any, error) {
+	buf := fmt.Appendf(nil, "*%d\r\n", len(args))
+	for _, arg := range args {
+		var data []byte
+		switch v := arg.(type) {
+		case string:
+			data = []byte(v)
+		case []byte:
+			data = v
+		default:
+			return nil, fmt.Errorf("unsupported redis argument %T", arg)
+		}
+
+		buf = fmt.Appendf(buf, "$%d\r\n", len(data))
+		buf = append(buf, data...)
+		buf = append(buf, '\r', '\n')
+	}
+
+	if _, err := c.conn.Write(buf); err != nil {
+		return nil, err
+	}
+
+	return c.readReply()
+}
+
+func (c *redisConn) readReply() (any, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("empty redis reply")
+	}
+
+	payload := string(line[1:])
+
+	switch line[0] {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, redisError(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+	case '*':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+
+		items := make([]any, n)
+		for i := range items {
+			item, err := c.readReply()
+			var replyErr redisError
+			if err != nil && !errors.As(err, &replyErr) {
+				return nil, err
+			}
+			items[i] = item
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unexpected redis reply %q", line)
+	}
+}
+
+func (c *redisConn) readLine() ([]byte, error) {
+	line, err := c.r.ReadSlice('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("malformed redis reply %q", line)
+	}
+
+	return line[:len(line)-2], nil
+}
@@ -0,0 +1,213 @@
+package pokemon
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Snapshot is a set of API responses loaded from an archive written by CacheManager.Export. A Resolver configured
+// with Config.Snapshot serves exclusively from it, without making any requests, which is useful for reproducible tests
+// and air-gapped environments.
+type Snapshot struct {
+	// response bodies by their URL relative to the base URL e.g., "pokemon/pikachu"
+	entries map[string][]byte
+}
+
+// LoadSnapshot reads a snapshot archive written by CacheManager.Export.
+func LoadSnapshot(r io.Reader) (*Snapshot, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	defer gz.Close()
+
+	snapshot := &Snapshot{entries: make(map[string][]byte)}
+
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return snapshot, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		body, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot entry %s: %w", header.Name, err)
+		}
+		snapshot.entries[header.Name] = body
+	}
+}
+
+// Len returns the number of responses in the snapshot.
+func (s *Snapshot) Len() int {
+	return len(s.entries)
+}
+
+// Export writes every cached response to w as a snapshot archive, a gzipped tarball with a file per response, and
+// returns the number of exported responses. Responses are stored relative to the base URL, so a snapshot can be
+// loaded by a Resolver with a different base URL. To export a selection of endpoints, warm them with Resolver.Warm
+// first. Expired responses, which are only kept to be served stale or revalidated, are left out, since importing them
+// would make them fresh again.
+//
+// The cache backend must implement CacheInspector, otherwise ErrCacheUnsupported is returned.
+func (m *CacheManager) Export(ctx context.Context, w io.Writer) (int, error) {
+	keys, err := m.Keys(ctx)
+	if err != nil {
+		return 0, err
+	}
+	// sorted, so that exporting the same responses results in the same archive
+	slices.Sort(keys)
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	exported, err := m.export(ctx, archive, keys)
+	// closed even if writing failed, so the gzip writer is released, but the error of the write is kept, since the
+	// archive is incomplete
+	if closeErr := errors.Join(archive.Close(), gz.Close()); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return exported, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return exported, nil
+}
+
+// export writes the fresh responses cached under the keys to the archive.
+func (m *CacheManager) export(ctx context.Context, archive *tar.Writer, keys []string) (int, error) {
+	now := time.Now()
+
+	var exported int
+	for _, key := range keys {
+		name, ok := strings.CutPrefix(key, m.client.baseURL+"/")
+		if !ok {
+			// not a response of the API e.g., a backend shared with other applications
+			continue
+		}
+
+		entry, found := m.client.lookup(ctx, key)
+		if !found || !entry.isFresh(now) {
+			// expired or evicted since listing the keys, or only kept to be served stale
+			continue
+		}
+
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(entry.body)),
+		}
+		if err := archive.WriteHeader(header); err != nil {
+			return exported, err
+		}
+		if _, err := archive.Write(entry.body); err != nil {
+			return exported, err
+		}
+		exported++
+	}
+
+	return exported, nil
+}
+
+// Import stores every response of the snapshot in the cache, with the configured TTL, and returns the number of
+// imported responses. Unlike Config.Snapshot, the Resolver keeps fetching responses missing from the snapshot. It stops
+// at the first response the cache fails to store.
+func (m *CacheManager) Import(ctx context.Context, snapshot *Snapshot) (int, error) {
+	if m.client.cache == nil {
+		return 0, nil
+	}
+
+	var imported int
+	for name, body := range snapshot.entries {
+		if err := ctx.Err(); err != nil {
+			return imported, err
+		}
+
+		data, ttl := m.client.encode(cacheEntry{body: body}, m.client.cacheTTL)
+		if err := m.client.cache.Set(ctx, m.client.baseURL+"/"+name, data, ttl); err != nil {
+			return imported, fmt.Errorf("failed to import %s: %w", name, err)
+		}
+		imported++
+	}
+
+	return imported, nil
+}
+
+// snapshotCache is the read-only CacheBackend of a Resolver serving from a Snapshot.
+type snapshotCache struct {
+	snapshot *Snapshot
+	baseURL  string
+}
+
+func (c *snapshotCache) Get(_ context.Context, key string) ([]byte, error) {
+	name, ok := strings.CutPrefix(key, c.baseURL+"/")
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	body, ok := c.snapshot.entries[name]
+	if !ok {
+		return nil, ErrCacheMiss
+	}
+
+	return body, nil
+}
+
+func (c *snapshotCache) Set(context.Context, string, []byte, time.Duration) error {
+	return ErrCacheUnsupported
+}
+
+func (c *snapshotCache) Delete(context.Context, string) error {
+	return ErrCacheUnsupported
+}
+
+func (c *snapshotCache) Keys(context.Context) ([]string, error) {
+	keys := make([]string, 0, len(c.snapshot.entries))
+	for name := range c.snapshot.entries {
+		keys = append(keys, c.baseURL+"/"+name)
+	}
+	slices.Sort(keys)
+
+	return keys, nil
+}
+
+func (c *snapshotCache) Flush(context.Context) error {
+	return ErrCacheUnsupported
+}
+
+func (c *snapshotCache) Usage(context.Context) (CacheUsage, error) {
+	usage := CacheUsage{Items: len(c.snapshot.entries)}
+	for _, body := range c.snapshot.entries {
+		usage.Bytes += int64(len(body))
+	}
+
+	return usage, nil
+}
+
+// offlineTransport answers every request with "404 Not Found", so a Resolver serving from a Snapshot returns
+// model.ErrNotFound for the responses missing from it, instead of making requests.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	return localResponse(req, http.StatusNotFound, nil), nil
+}
@@ -0,0 +1,93 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"errors"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"github.com/boyski33/pokemon-sdk/v2/pokemontest"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// strongestOf is an example of code depending on the API interface instead of the Resolver.
+func strongestOf(ctx context.Context, api pokemon.PokemonGetter, names ...string) (string, error) {
+	var strongest *model.Pokemon
+	for _, name := range names {
+		p, err := api.GetPokemon(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		if strongest == nil || p.BaseExperience > strongest.BaseExperience {
+			strongest = p
+		}
+	}
+
+	return strongest.Name, nil
+}
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+
+	fake := pokemontest.NewFake()
+	fake.AddPokemon(
+		&model.Pokemon{ID: 25, Name: "pikachu", BaseExperience: 112},
+		&model.Pokemon{ID: 6, Name: "charizard", BaseExperience: 267},
+		&model.Pokemon{ID: 1, Name: "bulbasaur", BaseExperience: 64},
+	)
+	fake.AddGeneration(&model.Generation{ID: 1, Name: "generation-i"})
+
+	t.Run("given fake when used as the api then behave like the resolver", func(t *testing.T) {
+		strongest, err := strongestOf(ctx, fake, "Pikachu", "6", "bulbasaur")
+		require.NoError(t, err)
+		require.Equal(t, "charizard", strongest)
+
+		_, err = fake.GetPokemon(ctx, "mewtwo")
+		require.ErrorIs(t, err, model.ErrNotFound)
+
+		g, err := fake.GetGeneration(ctx, "1")
+		require.NoError(t, err)
+		require.Equal(t, "generation-i", g.Name)
+	})
+
+	t.Run("given fake when listing then paginate in ID order", func(t *testing.T) {
+		page, err := fake.ListPokemon(ctx, 1, 2)
+		require.NoError(t, err)
+		require.Equal(t, []string{"bulbasaur", "charizard"}, page.Names())
+		require.Equal(t, 3, page.Count)
+		require.Equal(t, 2, page.TotalPages)
+		require.True(t, page.HasNext())
+
+		all, err := fake.AllPokemonNames(ctx)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+	})
+
+	t.Run("given fake with error when calling it then fail", func(t *testing.T) {
+		errDown := errors.New("down")
+		fake.SetError(errDown)
+		defer fake.SetError(nil)
+
+		_, err := fake.GetPokemon(ctx, "pikachu")
+		require.ErrorIs(t, err, errDown)
+		_, err = fake.ListGenerations(ctx, 1, 10)
+		require.ErrorIs(t, err, errDown)
+	})
+
+	t.Run("given resolver when used as the api then fetch from the server", func(t *testing.T) {
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu", BaseExperience: 112})
+		server.AddPokemon(&model.Pokemon{ID: 6, Name: "charizard", BaseExperience: 267})
+
+		var api pokemon.API = server.Resolver()
+		strongest, err := strongestOf(ctx, api, "pikachu", "charizard")
+		require.NoError(t, err)
+		require.Equal(t, "charizard", strongest)
+
+		page, err := api.ListPokemon(ctx, 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, []string{"charizard"}, page.Names())
+	})
+}
@@ -0,0 +1,87 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeAPIData writes a minimal api-data dump to dir, with an index of pokemonNames and pikachu as Pokemon 25.
+func writeAPIData(t *testing.T, dir string) {
+	t.Helper()
+
+	type namedResource struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+	index := struct {
+		Count   int             `json:"count"`
+		Results []namedResource `json:"results"`
+	}{Count: len(pokemonNames) + 1}
+	for i, name := range pokemonNames {
+		index.Results = append(index.Results, namedResource{Name: name, URL: fmt.Sprintf("/api/v2/pokemon/%d/", i+1)})
+	}
+	index.Results = append(index.Results, namedResource{Name: "pikachu", URL: "/api/v2/pokemon/25/"})
+
+	indexJSON, err := json.Marshal(index)
+	require.NoError(t, err)
+
+	pokemonDir := filepath.Join(dir, "data", "api", "v2", "pokemon")
+	require.NoError(t, os.MkdirAll(filepath.Join(pokemonDir, "25"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(pokemonDir, "index.json"), indexJSON, 0o644))
+	// the dump uses relative URLs
+	pikachu := strings.ReplaceAll(string(pikachuStub), "https://pokeapi.co/api/v2/", "/api/v2/")
+	require.NoError(t, os.WriteFile(filepath.Join(pokemonDir, "25", "index.json"), []byte(pikachu), 0o644))
+}
+
+func TestResolver_APIData(t *testing.T) {
+	dir := t.TempDir()
+	writeAPIData(t, dir)
+
+	resolver := pokemon.NewResolver().WithConfig(pokemon.Config{APIDataDir: dir})
+
+	t.Run("given api-data dump when getting a pokemon by id or name then read it from disk", func(t *testing.T) {
+		byID, err := resolver.Pokemon("25").Get()
+		require.NoError(t, err)
+		byName, err := resolver.Pokemon("Pikachu").Get()
+		require.NoError(t, err)
+
+		require.Equal(t, "pikachu", byID.Name)
+		require.Equal(t, byID, byName)
+
+		// THEN the relative URLs of the dump are rewritten
+		require.True(t, strings.HasPrefix(byID.Species.URL, "https://pokeapi.co/api/v2/"), byID.Species.URL)
+	})
+
+	t.Run("given api-data dump when listing pokemon then page through the index", func(t *testing.T) {
+		page, err := resolver.PokemonList(3, 3).GetPage(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 8, page.Count)
+		require.Equal(t, []string{"squirtle", "pikachu"}, page.Names())
+		require.Equal(t, "https://pokeapi.co/api/v2/pokemon/25/", page.Results[1].URL)
+
+		all, err := resolver.AllPokemonNames(context.Background())
+		require.NoError(t, err)
+		require.Len(t, all, 8)
+	})
+
+	t.Run("given api-data dump when getting missing resources then return not found", func(t *testing.T) {
+		_, err := resolver.Pokemon("mewtwo").Get()
+		require.ErrorIs(t, err, model.ErrNotFound)
+		_, err = resolver.Pokemon("150").Get()
+		require.ErrorIs(t, err, model.ErrNotFound)
+		_, err = resolver.Generation("generation-i").Get()
+		require.ErrorIs(t, err, model.ErrNotFound)
+		_, err = resolver.Pokemon("..").Get()
+		require.ErrorIs(t, err, model.ErrNotFound)
+	})
+}
@@ -0,0 +1,87 @@
+//go:build integration
+
+package test
+
+import (
+	"errors"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResolver_PokemonBatch(t *testing.T) {
+	t.Run("given some pokemon do not exist when getting a batch then return results in order with item errors", func(t *testing.T) {
+		// GIVEN a mock server which only knows pikachu
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/pokemon/pikachu" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL: mockServer.URL,
+		})
+
+		// WHEN getting a batch with a missing Pokemon in the middle
+		results, err := resolver.PokemonBatch("pikachu", "missingno", "pikachu").Get()
+
+		// THEN results are in input order with nil for the failed item
+		require.Len(t, results, 3)
+		require.Equal(t, "pikachu", results[0].Name)
+		require.Nil(t, results[1])
+		require.Equal(t, "pikachu", results[2].Name)
+
+		// THEN the error describes the failed item
+		require.ErrorIs(t, err, model.ErrNotFound)
+		var batchErr *pokemon.BatchError
+		require.True(t, errors.As(err, &batchErr))
+		require.Equal(t, 1, batchErr.Index)
+		require.Equal(t, "missingno", batchErr.ID)
+	})
+
+	t.Run("given worker limit when getting a batch then never exceed the limit", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		// GIVEN a slow mock server tracking concurrent requests
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				observed := maxInFlight.Load()
+				if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(genSevenStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN resolver with a limit of 2 workers
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			BatchWorkers: 2,
+		})
+
+		// WHEN getting a batch of distinct generations
+		results, err := resolver.GenerationBatch("1", "2", "3", "4", "5", "6").Get()
+
+		// THEN success
+		require.NoError(t, err)
+		require.Len(t, results, 6)
+
+		// THEN at most 2 requests were in flight
+		require.LessOrEqual(t, maxInFlight.Load(), int32(2))
+	})
+}
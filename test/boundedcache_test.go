@@ -0,0 +1,110 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBoundedCache(t *testing.T) {
+	ctx := context.Background()
+	// every entry is 100 bytes including the key
+	value := make([]byte, 99)
+
+	t.Run("given lru policy when the cache is full then evict the least recently used entry", func(t *testing.T) {
+		// GIVEN a cache which fits two entries
+		cache := pokemon.NewBoundedCache(pokemon.BoundedCacheOptions{MaxBytes: 250, Policy: pokemon.EvictLRU})
+		require.NoError(t, cache.Set(ctx, "a", value, 0))
+		require.NoError(t, cache.Set(ctx, "b", value, 0))
+
+		// GIVEN "a" was used more recently than "b"
+		_, err := cache.Get(ctx, "a")
+		require.NoError(t, err)
+
+		// WHEN adding a third entry
+		require.NoError(t, cache.Set(ctx, "c", value, 0))
+
+		// THEN "b" is evicted
+		_, err = cache.Get(ctx, "b")
+		require.ErrorIs(t, err, pokemon.ErrCacheMiss)
+		_, err = cache.Get(ctx, "a")
+		require.NoError(t, err)
+		_, err = cache.Get(ctx, "c")
+		require.NoError(t, err)
+	})
+
+	t.Run("given lfu policy when the cache is full then evict the least frequently used entry", func(t *testing.T) {
+		// GIVEN a cache which fits two entries
+		cache := pokemon.NewBoundedCache(pokemon.BoundedCacheOptions{MaxBytes: 250, Policy: pokemon.EvictLFU})
+		require.NoError(t, cache.Set(ctx, "a", value, 0))
+		require.NoError(t, cache.Set(ctx, "b", value, 0))
+
+		// GIVEN "a" is used more often, but "b" more recently
+		for range 3 {
+			_, err := cache.Get(ctx, "a")
+			require.NoError(t, err)
+		}
+		_, err := cache.Get(ctx, "b")
+		require.NoError(t, err)
+
+		// WHEN adding a third entry
+		require.NoError(t, cache.Set(ctx, "c", value, 0))
+
+		// THEN "b" is evicted
+		_, err = cache.Get(ctx, "b")
+		require.ErrorIs(t, err, pokemon.ErrCacheMiss)
+		_, err = cache.Get(ctx, "a")
+		require.NoError(t, err)
+	})
+
+	t.Run("given a cached key when setting a value larger than the cache then reject it and remove the old value", func(t *testing.T) {
+		// GIVEN
+		cache := pokemon.NewBoundedCache(pokemon.BoundedCacheOptions{MaxBytes: 250})
+		require.NoError(t, cache.Set(ctx, "a", value, 0))
+
+		// WHEN
+		err := cache.Set(ctx, "a", make([]byte, 300), 0)
+
+		// THEN the outdated value isn't served anymore
+		require.Error(t, err)
+		_, err = cache.Get(ctx, "a")
+		require.ErrorIs(t, err, pokemon.ErrCacheMiss)
+
+		usage, err := cache.Usage(ctx)
+		require.NoError(t, err)
+		require.Zero(t, usage.Items)
+		require.Zero(t, usage.Bytes)
+	})
+
+	t.Run("given max bytes in config when cache is enabled then use a bounded cache", func(t *testing.T) {
+		var mockServerInvocations int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations++
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN a cache too small for a single response
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:       mockServer.URL,
+			CacheEnabled:  true,
+			CacheMaxBytes: 100,
+		})
+
+		// WHEN calling twice
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN nothing is cached
+		require.Equal(t, 2, mockServerInvocations)
+	})
+}
@@ -0,0 +1,89 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mapCache is a minimal custom pokemon.CacheBackend.
+type mapCache struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	ttls    map[string]time.Duration
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{entries: map[string][]byte{}, ttls: map[string]time.Duration{}}
+}
+
+func (c *mapCache) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, ok := c.entries[key]
+	if !ok {
+		return nil, pokemon.ErrCacheMiss
+	}
+	return value, nil
+}
+
+func (c *mapCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = value
+	c.ttls[key] = ttl
+	return nil
+}
+
+func (c *mapCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	delete(c.ttls, key)
+	return nil
+}
+
+func TestResolver_CustomCache(t *testing.T) {
+	t.Run("given custom cache backend when getting pokemon twice then use the backend", func(t *testing.T) {
+		var mockServerInvocations int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations++
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN resolver with a custom cache backend
+		backend := newMapCache()
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:  mockServer.URL,
+			Cache:    backend,
+			CacheTTL: time.Minute,
+		})
+
+		// WHEN calling twice
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN server only called once
+		require.Equal(t, 1, mockServerInvocations)
+
+		// THEN the response is stored in the backend with the configured TTL
+		key := mockServer.URL + "/pokemon/pikachu"
+		require.Equal(t, pikachuStub, backend.entries[key])
+		require.Equal(t, time.Minute, backend.ttls[key])
+	})
+}
@@ -0,0 +1,114 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolver_Cache(t *testing.T) {
+	ctx := context.Background()
+
+	// GIVEN a mock server returning stubs for Pokemon and generations
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if strings.HasPrefix(r.URL.Path, "/generation/") {
+			_, _ = w.Write(genSevenStub)
+			return
+		}
+		_, _ = w.Write(pikachuStub)
+	}))
+	defer mockServer.Close()
+
+	t.Run("given cached responses when getting stats then report hits, misses and usage", func(t *testing.T) {
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+		})
+
+		// GIVEN one miss followed by two hits
+		for range 3 {
+			_, err := resolver.Pokemon("pikachu").Get()
+			require.NoError(t, err)
+		}
+
+		// WHEN getting the stats
+		stats, err := resolver.Cache().Stats(ctx)
+
+		// THEN the counters and usage are reported
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), stats.Hits)
+		require.Equal(t, uint64(1), stats.Misses)
+		require.InDelta(t, 2.0/3.0, stats.HitRatio(), 0.001)
+		// THEN the response is cached under both the name and the ID
+		require.Equal(t, 2, stats.Items)
+		require.Greater(t, stats.Bytes, int64(len(pikachuStub)))
+	})
+
+	t.Run("given cached pokemon and generation when purging pokemon then only generation remains", func(t *testing.T) {
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:       mockServer.URL,
+			CacheEnabled:  true,
+			CacheMaxBytes: 10 << 20,
+		})
+
+		_, err := resolver.PokemonBatch("pikachu", "raichu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Generation("7").Get()
+		require.NoError(t, err)
+
+		// WHEN purging every Pokemon
+		purged, err := resolver.Cache().PurgePrefix(ctx, "/pokemon/")
+
+		// THEN only the generation remains
+		require.NoError(t, err)
+		require.Equal(t, 3, purged) // pikachu, raichu and 25, since both are served the pikachu stub
+		keys, err := resolver.Cache().Keys(ctx)
+		require.NoError(t, err)
+		generationKeys := []string{mockServer.URL + "/generation/7", mockServer.URL + "/generation/generation-vii"}
+		require.ElementsMatch(t, generationKeys, keys)
+
+		// WHEN deleting the generation
+		for _, key := range generationKeys {
+			require.NoError(t, resolver.Cache().Delete(ctx, key))
+		}
+
+		// THEN the cache is empty
+		stats, err := resolver.Cache().Stats(ctx)
+		require.NoError(t, err)
+		require.Zero(t, stats.Items)
+		require.Zero(t, stats.Bytes)
+	})
+
+	t.Run("given disk cache when flushing then remove every entry", func(t *testing.T) {
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:  mockServer.URL,
+			CacheDir: t.TempDir(),
+		})
+
+		_, err := resolver.PokemonBatch("pikachu", "raichu").Get()
+		require.NoError(t, err)
+
+		keys, err := resolver.Cache().Keys(ctx)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{
+			mockServer.URL + "/pokemon/pikachu",
+			mockServer.URL + "/pokemon/raichu",
+			mockServer.URL + "/pokemon/25",
+		}, keys)
+
+		// WHEN flushing
+		require.NoError(t, resolver.Cache().Flush(ctx))
+
+		// THEN the cache is empty
+		stats, err := resolver.Cache().Stats(ctx)
+		require.NoError(t, err)
+		require.Zero(t, stats.Items)
+	})
+}
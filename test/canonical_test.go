@@ -0,0 +1,62 @@
+//go:build integration
+
+package test
+
+import (
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolver_CanonicalCacheKeys(t *testing.T) {
+	t.Run("given pokemon fetched by id when getting it by name in any case then hit the cache", func(t *testing.T) {
+		var requestedPaths []string
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestedPaths = append(requestedPaths, r.URL.Path)
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+		})
+
+		// WHEN getting the same Pokemon by ID, by name and by a name which isn't normalized
+		for _, id := range []string{"25", "pikachu", " Pikachu"} {
+			p, err := resolver.Pokemon(id).Get()
+			require.NoError(t, err)
+			require.Equal(t, "pikachu", p.Name)
+		}
+
+		// THEN server only called once
+		require.Equal(t, []string{"/pokemon/25"}, requestedPaths)
+	})
+
+	t.Run("given generation fetched by name when getting it by id then hit the cache", func(t *testing.T) {
+		var mockServerInvocations int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations++
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(genSevenStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+		})
+
+		_, err := resolver.Generation("GENERATION-VII").Get()
+		require.NoError(t, err)
+		_, err = resolver.Generation("7").Get()
+		require.NoError(t, err)
+
+		require.Equal(t, 1, mockServerInvocations)
+	})
+}
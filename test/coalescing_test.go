@@ -0,0 +1,99 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResolver_RequestCoalescing(t *testing.T) {
+	t.Run("given cold cache when getting the same pokemon concurrently then only call server once", func(t *testing.T) {
+		var mockServerInvocations atomic.Int32
+		// GIVEN a slow mock server, so that all callers overlap
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations.Add(1)
+			time.Sleep(100 * time.Millisecond)
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN resolver with enabled cache
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+		})
+
+		// WHEN 50 goroutines get the same Pokemon
+		var wg sync.WaitGroup
+		errs := make(chan error, 50)
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := resolver.Pokemon("pikachu").Get()
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// THEN all succeed
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		// THEN server only called once
+		require.Equal(t, int32(1), mockServerInvocations.Load())
+	})
+
+	t.Run("given leader context is cancelled when other callers wait for the same pokemon then they still succeed", func(t *testing.T) {
+		var mockServerInvocations atomic.Int32
+		// GIVEN a slow mock server
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations.Add(1)
+			time.Sleep(100 * time.Millisecond)
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL: mockServer.URL,
+		})
+
+		// GIVEN a leader whose context is cancelled before the response arrives
+		leaderCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err := resolver.Pokemon("pikachu").GetWithContext(leaderCtx)
+			leaderErr <- err
+		}()
+
+		// WHEN a follower joins the in-flight request
+		time.Sleep(5 * time.Millisecond)
+		pikachu, err := resolver.Pokemon("pikachu").Get()
+
+		// THEN the leader fails with its own context error
+		require.ErrorIs(t, <-leaderErr, context.DeadlineExceeded)
+
+		// THEN the follower succeeds
+		require.NoError(t, err)
+		require.Equal(t, "pikachu", pikachu.Name)
+
+		// THEN server only called once
+		require.Equal(t, int32(1), mockServerInvocations.Load())
+	})
+}
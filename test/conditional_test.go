@@ -0,0 +1,172 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResolver_ConditionalRequests(t *testing.T) {
+	t.Run("given cached response with etag when it expires then revalidate it", func(t *testing.T) {
+		var fullResponses, notModifiedResponses atomic.Int32
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"pikachu-v1"`)
+			if r.Header.Get("If-None-Match") == `"pikachu-v1"` {
+				notModifiedResponses.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			fullResponses.Add(1)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+			CacheTTL:     500 * time.Millisecond,
+		})
+
+		first, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		time.Sleep(600 * time.Millisecond)
+
+		// WHEN getting the expired Pokemon
+		revalidated, err := resolver.Pokemon("pikachu").Get()
+
+		// THEN the cached Pokemon is revalidated instead of downloaded again
+		require.NoError(t, err)
+		require.Equal(t, first, revalidated)
+		require.Equal(t, int32(1), fullResponses.Load())
+		require.Equal(t, int32(1), notModifiedResponses.Load())
+
+		// THEN the 304 refreshed the entry
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		require.Equal(t, int32(1), notModifiedResponses.Load())
+	})
+
+	t.Run("given cached response with last modified when it changed then download it again", func(t *testing.T) {
+		var ifModifiedSince []string
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ifModifiedSince = append(ifModifiedSince, r.Header.Get("If-Modified-Since"))
+
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+			CacheTTL:     10 * time.Millisecond,
+		})
+
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"", "Mon, 02 Jan 2006 15:04:05 GMT"}, ifModifiedSince)
+	})
+
+	t.Run("given cached response with etag when its alias expires then revalidate the alias", func(t *testing.T) {
+		var fullResponses, notModifiedResponses atomic.Int32
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"pikachu-v1"`)
+			if r.Header.Get("If-None-Match") == `"pikachu-v1"` {
+				notModifiedResponses.Add(1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			fullResponses.Add(1)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver(
+			pokemon.WithBaseURL(mockServer.URL),
+			pokemon.WithCacheEnabled(true),
+			pokemon.WithCacheTTL(500*time.Millisecond),
+		)
+
+		// GIVEN a Pokemon fetched by name, which is also cached under its ID
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		time.Sleep(600 * time.Millisecond)
+
+		// WHEN getting the expired Pokemon by ID
+		p, err := resolver.Pokemon("25").Get()
+
+		// THEN the alias is revalidated instead of downloaded again
+		require.NoError(t, err)
+		require.Equal(t, "pikachu", p.Name)
+		require.Equal(t, int32(1), fullResponses.Load())
+		require.Equal(t, int32(1), notModifiedResponses.Load())
+	})
+
+	t.Run("given cached response with etag when storing it then expire it after the revalidation window", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"pikachu-v1"`)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN a backend recording the TTLs it is given
+		backend := &ttlRecordingCache{CacheBackend: pokemon.NewCache(0)}
+		resolver := pokemon.NewResolver(
+			pokemon.WithBaseURL(mockServer.URL),
+			pokemon.WithCache(backend),
+			pokemon.WithCacheTTL(time.Minute),
+			pokemon.WithRevalidationWindow(time.Hour),
+		)
+
+		// WHEN
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN the entry expires in the backend instead of staying forever
+		require.Equal(t, time.Hour+time.Minute, backend.ttl(mockServer.URL+"/pokemon/pikachu"))
+	})
+}
+
+// ttlRecordingCache records the TTL every key was last stored with.
+type ttlRecordingCache struct {
+	pokemon.CacheBackend
+
+	mu   sync.Mutex
+	ttls map[string]time.Duration
+}
+
+func (c *ttlRecordingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	if c.ttls == nil {
+		c.ttls = make(map[string]time.Duration)
+	}
+	c.ttls[key] = ttl
+	c.mu.Unlock()
+
+	return c.CacheBackend.Set(ctx, key, value, ttl)
+}
+
+func (c *ttlRecordingCache) ttl(key string) time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ttls[key]
+}
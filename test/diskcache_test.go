@@ -0,0 +1,97 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDiskCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("given disk cache when resolver is recreated then serve responses from disk", func(t *testing.T) {
+		var mockServerInvocations int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations++
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN config with a compressed disk cache
+		config := pokemon.Config{
+			BaseURL:          mockServer.URL,
+			CacheDir:         t.TempDir(),
+			CacheCompression: true,
+		}
+
+		// WHEN getting a Pokemon with one resolver and then with a new one, as if the process restarted
+		fromServer, err := pokemon.NewResolver().WithConfig(config).Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		fromDisk, err := pokemon.NewResolver().WithConfig(config).Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN server only called once
+		require.Equal(t, 1, mockServerInvocations)
+		require.Equal(t, fromServer, fromDisk)
+
+		// THEN the stored files, for the name and the ID, are compressed
+		files, err := os.ReadDir(config.CacheDir)
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		for _, file := range files {
+			info, err := file.Info()
+			require.NoError(t, err)
+			require.Less(t, info.Size(), int64(len(pikachuStub)))
+		}
+	})
+
+	t.Run("given entry with ttl when it expires then return cache miss", func(t *testing.T) {
+		cache := pokemon.NewDiskCache(t.TempDir(), pokemon.DiskCacheOptions{})
+
+		require.NoError(t, cache.Set(ctx, "key", []byte("value"), 10*time.Millisecond))
+		value, err := cache.Get(ctx, "key")
+		require.NoError(t, err)
+		require.Equal(t, []byte("value"), value)
+
+		time.Sleep(20 * time.Millisecond)
+		_, err = cache.Get(ctx, "key")
+		require.ErrorIs(t, err, pokemon.ErrCacheMiss)
+	})
+
+	t.Run("given size cap when it is exceeded then evict the least recently used entries", func(t *testing.T) {
+		// GIVEN a cache which only fits two entries
+		dir := t.TempDir()
+		value := make([]byte, 1000)
+		cache := pokemon.NewDiskCache(dir, pokemon.DiskCacheOptions{MaxBytes: 2500})
+
+		require.NoError(t, cache.Set(ctx, "first", value, 0))
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, cache.Set(ctx, "second", value, 0))
+		time.Sleep(10 * time.Millisecond)
+
+		// GIVEN the first entry was used recently
+		_, err := cache.Get(ctx, "first")
+		require.NoError(t, err)
+		time.Sleep(10 * time.Millisecond)
+
+		// WHEN adding a third entry
+		require.NoError(t, cache.Set(ctx, "third", value, 0))
+
+		// THEN the least recently used entry is evicted
+		_, err = cache.Get(ctx, "second")
+		require.ErrorIs(t, err, pokemon.ErrCacheMiss)
+		_, err = cache.Get(ctx, "first")
+		require.NoError(t, err)
+		_, err = cache.Get(ctx, "third")
+		require.NoError(t, err)
+	})
+}
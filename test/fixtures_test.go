@@ -0,0 +1,122 @@
+//go:build integration
+
+package test
+
+import (
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolver_Fixtures(t *testing.T) {
+	t.Run("given recorded fixtures when replaying then serve them without the server", func(t *testing.T) {
+		dir := t.TempDir()
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/pokemon/pikachu" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+
+		// GIVEN fixtures recorded against the server
+		recorder := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			FixturesDir:  dir,
+			FixturesMode: pokemon.FixturesRecord,
+		})
+		recorded, err := recorder.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = recorder.Pokemon("missingno").Get()
+		require.ErrorIs(t, err, model.ErrNotFound)
+		require.FileExists(t, filepath.Join(dir, "pokemon_pikachu.json"))
+		require.FileExists(t, filepath.Join(dir, "pokemon_missingno.json"))
+
+		// GIVEN the server is gone
+		mockServer.Close()
+
+		// WHEN replaying with another base URL
+		replayer := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:     "http://localhost:1",
+			FixturesDir: dir,
+		})
+
+		// THEN the recorded responses are served
+		replayed, err := replayer.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		require.Equal(t, recorded, replayed)
+		_, err = replayer.Pokemon("missingno").Get()
+		require.ErrorIs(t, err, model.ErrNotFound)
+
+		// THEN requests which were never recorded fail
+		_, err = replayer.Pokemon("bulbasaur").Get()
+		require.ErrorIs(t, err, pokemon.ErrFixtureNotFound)
+	})
+
+	t.Run("given a recorded etag when replaying then keep the validator", func(t *testing.T) {
+		dir := t.TempDir()
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"v1"`)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+
+		// GIVEN a fixture recorded with an ETag
+		recorder := pokemon.NewResolver(
+			pokemon.WithBaseURL(mockServer.URL),
+			pokemon.WithFixtures(dir, pokemon.FixturesRecord),
+		)
+		_, err := recorder.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		mockServer.Close()
+
+		// WHEN replaying it
+		var etag string
+		replayer := pokemon.NewResolver(
+			pokemon.WithFixtures(dir, pokemon.FixturesReplay),
+			pokemon.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+				return pokemon.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					resp, err := next.RoundTrip(req)
+					if err == nil {
+						etag = resp.Header.Get("ETag")
+					}
+					return resp, err
+				})
+			}),
+		)
+		_, err = replayer.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN
+		require.Equal(t, `"v1"`, etag)
+	})
+
+	t.Run("given fixtures env var when it is set to record then override the config", func(t *testing.T) {
+		dir := t.TempDir()
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		t.Setenv(pokemon.FixturesEnv, "record")
+
+		_, err := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			FixturesDir:  dir,
+			FixturesMode: pokemon.FixturesReplay,
+		}).Pokemon("pikachu").Get()
+
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(dir, "pokemon_pikachu.json"))
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,48 @@
+//go:build integration
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// writeNamedResourceList writes a paginated list response, like the one returned by the public API, for the given
+// names based on the limit and offset query parameters of the request.
+func writeNamedResourceList(w http.ResponseWriter, r *http.Request, resource string, names []string) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	type namedResource struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+	type response struct {
+		Count    int             `json:"count"`
+		Next     *string         `json:"next"`
+		Previous *string         `json:"previous"`
+		Results  []namedResource `json:"results"`
+	}
+
+	resp := response{Count: len(names), Results: []namedResource{}}
+	for i := offset; i < min(offset+limit, len(names)); i++ {
+		resp.Results = append(resp.Results, namedResource{
+			Name: names[i],
+			URL:  fmt.Sprintf("http://%s/%s/%d/", r.Host, resource, i+1),
+		})
+	}
+
+	if offset+limit < len(names) {
+		next := fmt.Sprintf("http://%s/%s?offset=%d&limit=%d", r.Host, resource, offset+limit, limit)
+		resp.Next = &next
+	}
+	if offset > 0 {
+		previous := fmt.Sprintf("http://%s/%s?offset=%d&limit=%d", r.Host, resource, max(offset-limit, 0), limit)
+		resp.Previous = &previous
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,54 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResolver_AllPokemonNames(t *testing.T) {
+	t.Run("given cache enabled when getting all names twice then fetch the full index once", func(t *testing.T) {
+		var probeRequests, indexRequests atomic.Int32
+		// GIVEN a mock server with 7 Pokemon names
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("limit") == "1" {
+				probeRequests.Add(1)
+			} else {
+				indexRequests.Add(1)
+			}
+			writeNamedResourceList(w, r, "pokemon", pokemonNames)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+		})
+
+		// WHEN getting all names
+		all, err := resolver.AllPokemonNames(context.Background())
+
+		// THEN every Pokemon is returned
+		require.NoError(t, err)
+		require.Len(t, all, len(pokemonNames))
+		for i, res := range all {
+			require.Equal(t, pokemonNames[i], res.Name)
+			require.NotEmpty(t, res.URL)
+		}
+
+		// WHEN getting all names again
+		again, err := resolver.AllPokemonNames(context.Background())
+
+		// THEN both the count probe and the full index are served from the cache
+		require.NoError(t, err)
+		require.Equal(t, all, again)
+		require.Equal(t, int32(1), probeRequests.Load())
+		require.Equal(t, int32(1), indexRequests.Load())
+	})
+}
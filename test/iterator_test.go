@@ -0,0 +1,95 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var pokemonNames = []string{"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon", "charizard", "squirtle"}
+
+func TestPokemonList_All(t *testing.T) {
+	// GIVEN a mock server with 7 Pokemon names
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pokemon" {
+			writeNamedResourceList(w, r, "pokemon", pokemonNames)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(pikachuStub)
+	}))
+	defer mockServer.Close()
+
+	resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+		BaseURL: mockServer.URL,
+	})
+
+	t.Run("given list with page size 3 when iterating all names then yield every name in order", func(t *testing.T) {
+		list := resolver.PokemonList(1, 3)
+
+		// WHEN iterating all names
+		var names []string
+		for name, err := range list.All(context.Background()) {
+			require.NoError(t, err)
+			names = append(names, name)
+		}
+
+		// THEN every name is yielded
+		require.Equal(t, pokemonNames, names)
+
+		// THEN the cursor used by Next is not moved
+		firstPage, err := list.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, pokemonNames[:3], firstPage)
+	})
+
+	t.Run("given list with page size 0 when iterating all names then terminate", func(t *testing.T) {
+		// WHEN iterating all names
+		var names []string
+		for name, err := range resolver.PokemonList(1, 0).All(context.Background()) {
+			require.NoError(t, err)
+			names = append(names, name)
+			// guards against the iteration never ending
+			require.LessOrEqual(t, len(names), len(pokemonNames))
+		}
+
+		// THEN every name is yielded once, with the default page size
+		require.Equal(t, pokemonNames, names)
+
+		page, err := resolver.PokemonList(1, -5).GetPage(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 20, page.Size)
+		require.Equal(t, 1, page.TotalPages)
+		require.False(t, page.HasNext())
+	})
+
+	t.Run("given list when breaking out of the iteration early then stop yielding", func(t *testing.T) {
+		var names []string
+		for name, err := range resolver.PokemonList(2, 3).All(context.Background()) {
+			require.NoError(t, err)
+			names = append(names, name)
+			if len(names) == 4 {
+				break
+			}
+		}
+
+		require.Equal(t, pokemonNames[3:7], names)
+	})
+
+	t.Run("given list when iterating all pokemon then yield fully fetched pokemon", func(t *testing.T) {
+		var count int
+		for p, err := range resolver.PokemonList(1, 5).AllPokemon(context.Background()) {
+			require.NoError(t, err)
+			require.Equal(t, "pikachu", p.Name)
+			count++
+		}
+
+		require.Equal(t, len(pokemonNames), count)
+	})
+}
@@ -0,0 +1,149 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPokemonList_Pages(t *testing.T) {
+	// GIVEN a mock server with 7 Pokemon names
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeNamedResourceList(w, r, "pokemon", pokemonNames)
+	}))
+	defer mockServer.Close()
+
+	resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+		BaseURL: mockServer.URL,
+	})
+	ctx := context.Background()
+
+	t.Run("given list when getting a page then return page metadata", func(t *testing.T) {
+		page, err := resolver.PokemonList(2, 3).GetPage(ctx)
+
+		require.NoError(t, err)
+		require.Equal(t, 2, page.Number)
+		require.Equal(t, 3, page.Size)
+		require.Equal(t, 7, page.Count)
+		require.Equal(t, 3, page.TotalPages)
+		require.NotEmpty(t, page.Next)
+		require.NotEmpty(t, page.Previous)
+		require.True(t, page.HasNext())
+		require.True(t, page.HasPrevious())
+		require.Equal(t, pokemonNames[3:6], page.Names())
+		require.Equal(t, "charmander", page.Results[0].Name)
+		require.NotEmpty(t, page.Results[0].URL)
+	})
+
+	t.Run("given list when moving back and forth then return the expected pages", func(t *testing.T) {
+		list := resolver.PokemonList(1, 3)
+
+		// WHEN there is no previous page yet
+		_, err := list.Prev(ctx)
+		require.ErrorIs(t, err, io.EOF)
+
+		// WHEN going forward twice and back once
+		_, err = list.Next(ctx)
+		require.NoError(t, err)
+		_, err = list.Next(ctx)
+		require.NoError(t, err)
+		prev, err := list.Prev(ctx)
+
+		// THEN the first page is returned
+		require.NoError(t, err)
+		require.Equal(t, pokemonNames[:3], prev)
+
+		// THEN going forward again returns the second page
+		next, err := list.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, pokemonNames[3:6], next)
+	})
+
+	t.Run("given list when seeking and resetting then next returns the expected pages", func(t *testing.T) {
+		list := resolver.PokemonList(1, 3)
+
+		// WHEN seeking to the last page
+		list.Seek(3)
+		last, err := list.NextPage(ctx)
+
+		// THEN the last page is returned and the list is exhausted
+		require.NoError(t, err)
+		require.False(t, last.HasNext())
+		require.Equal(t, pokemonNames[6:], last.Names())
+		_, err = list.Next(ctx)
+		require.ErrorIs(t, err, io.EOF)
+
+		// WHEN resetting
+		list.Reset()
+		first, err := list.Next(ctx)
+
+		// THEN the first page is returned
+		require.NoError(t, err)
+		require.Equal(t, pokemonNames[:3], first)
+	})
+}
+
+func TestPokemonList_Cache(t *testing.T) {
+	t.Run("given cache enabled when iterating a list twice then serve the second run from the cache", func(t *testing.T) {
+		var mockServerInvocations int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations++
+			writeNamedResourceList(w, r, "pokemon", pokemonNames)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN a disk cache, so the second run could be another process
+		config := pokemon.Config{
+			BaseURL:  mockServer.URL,
+			CacheDir: t.TempDir(),
+		}
+		ctx := context.Background()
+
+		for range 2 {
+			// WHEN iterating the whole list with Next
+			list := pokemon.NewResolver().WithConfig(config).PokemonList(1, 3)
+
+			var names []string
+			for page, err := list.Next(ctx); err != io.EOF; page, err = list.Next(ctx) {
+				require.NoError(t, err)
+				names = append(names, page...)
+			}
+			require.Equal(t, pokemonNames, names)
+		}
+
+		// THEN server only called once per page
+		require.Equal(t, 3, mockServerInvocations)
+	})
+
+	t.Run("given list cache ttl when the list pages expire then fetch them again", func(t *testing.T) {
+		var mockServerInvocations int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations++
+			writeNamedResourceList(w, r, "pokemon", pokemonNames)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN list pages expire much sooner than single resources
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+			CacheTTL:     time.Hour,
+			ListCacheTTL: 10 * time.Millisecond,
+		})
+
+		_, err := resolver.PokemonList(1, 3).Get()
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+		_, err = resolver.PokemonList(1, 3).Get()
+		require.NoError(t, err)
+
+		require.Equal(t, 2, mockServerInvocations)
+	})
+}
@@ -0,0 +1,71 @@
+//go:build integration
+
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolver_Logger(t *testing.T) {
+	t.Run("given logger when getting pokemon then log requests and cache lookups", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/pokemon/broken" {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN a logger writing JSON lines
+		var logs bytes.Buffer
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+			Logger:       slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		})
+
+		// WHEN getting a Pokemon twice and a broken one
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Pokemon("broken").Get()
+		require.Error(t, err)
+
+		// THEN every event is logged with its attributes
+		var records []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+			var record map[string]any
+			require.NoError(t, json.Unmarshal([]byte(line), &record))
+			records = append(records, record)
+		}
+
+		var messages []string
+		for _, record := range records {
+			messages = append(messages, record["msg"].(string))
+		}
+		require.Equal(t, []string{"cache miss", "fetched", "cache hit", "cache miss", "unexpected status code"}, messages)
+
+		fetched := records[1]
+		require.Equal(t, "DEBUG", fetched["level"])
+		require.Equal(t, mockServer.URL+"/pokemon/pikachu", fetched["url"])
+		require.Equal(t, float64(http.StatusOK), fetched["status"])
+		require.Equal(t, float64(len(pikachuStub)), fetched["bytes"])
+		require.Contains(t, fetched, "duration")
+
+		failed := records[4]
+		require.Equal(t, "WARN", failed["level"])
+		require.Equal(t, float64(http.StatusBadGateway), failed["status"])
+	})
+}
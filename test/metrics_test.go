@@ -0,0 +1,81 @@
+//go:build integration
+
+package test
+
+import (
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/pokemonprom"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPrometheusCollector(t *testing.T) {
+	t.Run("given a registered prometheus collector when making requests then expose the metrics", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/pokemon/pikachu":
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write(pikachuStub)
+			case "/pokemon":
+				writeNamedResourceList(w, r, "pokemon", pokemonNames)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer mockServer.Close()
+
+		// GIVEN a collector registered next to the metrics of the application
+		collector := pokemonprom.NewCollector(pokemonprom.Options{})
+		registry := prometheus.NewRegistry()
+		require.NoError(t, registry.Register(collector))
+		require.NoError(t, registry.Register(prometheus.NewCounter(prometheus.CounterOpts{Name: "app_requests_total"})))
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+			Metrics:      collector,
+		})
+
+		// WHEN getting a Pokemon twice, a missing one and a list page
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Pokemon("missingno").Get()
+		require.Error(t, err)
+		_, err = resolver.PokemonList(1, 3).Get()
+		require.NoError(t, err)
+
+		// WHEN scraping the metrics
+		recorder := httptest.NewRecorder()
+		promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+		lines := strings.Split(recorder.Body.String(), "\n")
+
+		// THEN requests, latency, bytes and cache lookups are exposed by endpoint
+		for _, expected := range []string{
+			"# TYPE pokemon_sdk_requests_total counter",
+			`pokemon_sdk_requests_total{endpoint="/pokemon/{id}",status="200"} 1`,
+			`pokemon_sdk_requests_total{endpoint="/pokemon/{id}",status="404"} 1`,
+			`pokemon_sdk_requests_total{endpoint="/pokemon",status="200"} 1`,
+			"# TYPE pokemon_sdk_request_duration_seconds histogram",
+			`pokemon_sdk_request_duration_seconds_bucket{endpoint="/pokemon/{id}",le="+Inf"} 2`,
+			`pokemon_sdk_request_duration_seconds_count{endpoint="/pokemon/{id}"} 2`,
+			"pokemon_sdk_requests_in_flight 0",
+			`pokemon_sdk_response_bytes_total{endpoint="/pokemon/{id}"} ` + strconv.Itoa(len(pikachuStub)),
+			`pokemon_sdk_cache_lookups_total{endpoint="/pokemon/{id}",result="hit"} 1`,
+			`pokemon_sdk_cache_lookups_total{endpoint="/pokemon/{id}",result="miss"} 2`,
+			`pokemon_sdk_cache_lookups_total{endpoint="/pokemon",result="miss"} 1`,
+			"pokemon_sdk_cache_hit_ratio 0.25",
+			"app_requests_total 0",
+		} {
+			require.Contains(t, lines, expected)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+//go:build integration
+
+package test
+
+import (
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResolver_HTTPClient(t *testing.T) {
+	t.Run("given http client and middleware when getting a pokemon then apply them in order", func(t *testing.T) {
+		var userAgent, authorization string
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userAgent = r.Header.Get("User-Agent")
+			authorization = r.Header.Get("Authorization")
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN a custom client with its own transport
+		var transportCalls atomic.Int32
+		httpClient := &http.Client{
+			Transport: pokemon.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				transportCalls.Add(1)
+				return http.DefaultTransport.RoundTrip(req)
+			}),
+		}
+
+		// GIVEN middleware which records the order it is called in
+		var order []string
+		record := func(name string) pokemon.Middleware {
+			return func(next http.RoundTripper) http.RoundTripper {
+				return pokemon.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					order = append(order, name)
+					return next.RoundTrip(req)
+				})
+			}
+		}
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:       mockServer.URL,
+			HTTPClient:    httpClient,
+			ClientTimeout: time.Second,
+			Middleware: []pokemon.Middleware{
+				record("first"),
+				pokemon.UserAgent("my-service/1.0"),
+				pokemon.SetHeader("Authorization", "Bearer token"),
+				record("last"),
+			},
+		})
+
+		_, err := resolver.Pokemon("pikachu").Get()
+
+		// THEN the request went through the middleware and the custom transport
+		require.NoError(t, err)
+		require.Equal(t, []string{"first", "last"}, order)
+		require.Equal(t, "my-service/1.0", userAgent)
+		require.Equal(t, "Bearer token", authorization)
+		require.Equal(t, int32(1), transportCalls.Load())
+
+		// THEN the custom client wasn't changed
+		require.Zero(t, httpClient.Timeout)
+	})
+}
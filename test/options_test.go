@@ -0,0 +1,222 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"github.com/boyski33/pokemon-sdk/v2/pokemontest"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResolver_Options(t *testing.T) {
+	t.Run("given options when getting a pokemon then apply them", func(t *testing.T) {
+		// GIVEN
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+
+		var userAgent string
+		resolver := pokemon.NewResolver(
+			pokemon.WithBaseURL(server.URL),
+			pokemon.WithCacheEnabled(true),
+			pokemon.WithCacheTTL(time.Minute),
+			pokemon.WithMiddleware(pokemon.UserAgent("my-service/1.0")),
+			pokemon.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+				return pokemon.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					userAgent = req.Header.Get("User-Agent")
+					return next.RoundTrip(req)
+				})
+			}),
+		)
+
+		// WHEN
+		for range 2 {
+			p, err := resolver.Pokemon("pikachu").Get()
+			require.NoError(t, err)
+			require.Equal(t, 25, p.ID)
+		}
+
+		// THEN the response is cached and the middleware is applied
+		require.Equal(t, 1, server.Calls("/pokemon/pikachu"))
+		require.Equal(t, "my-service/1.0", userAgent)
+	})
+
+	t.Run("given a config option followed by other options when getting a pokemon then the later options win", func(t *testing.T) {
+		// GIVEN
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+
+		resolver := pokemon.NewResolver(
+			pokemon.FromConfig(pokemon.Config{BaseURL: "http://localhost:1", CacheEnabled: true, CacheTTL: time.Minute}),
+			pokemon.WithBaseURL(server.URL),
+		)
+
+		// WHEN
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN
+		require.Equal(t, 1, server.Calls("/pokemon/pikachu"))
+	})
+}
+
+func TestResolver_WithConfig(t *testing.T) {
+	t.Run("given a resolver when calling with config then configure it in place", func(t *testing.T) {
+		// GIVEN
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+
+		resolver := pokemon.NewResolver()
+
+		// WHEN ignoring the returned resolver, as code written for earlier versions does
+		resolver.WithConfig(pokemon.Config{BaseURL: server.URL, CacheEnabled: true, CacheTTL: time.Minute})
+
+		// THEN the config is applied to the resolver
+		for range 2 {
+			_, err := resolver.Pokemon("pikachu").Get()
+			require.NoError(t, err)
+		}
+		require.Equal(t, 1, server.Calls("/pokemon/pikachu"))
+	})
+}
+
+func TestResolver_Clone(t *testing.T) {
+	t.Run("given a cached resolver when cloning it with a timeout then share the cache and its stats", func(t *testing.T) {
+		// GIVEN
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+		resolver := server.Resolver(pokemon.Config{CacheEnabled: true, CacheTTL: time.Minute})
+
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// WHEN
+		clone := resolver.Clone(pokemon.WithClientTimeout(time.Second))
+		_, err = clone.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN the clone is served from the cache of the original
+		require.Equal(t, 1, server.Calls("/pokemon/pikachu"))
+
+		stats, err := resolver.Cache().Stats(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), stats.Hits)
+		require.Equal(t, uint64(1), stats.Misses)
+	})
+
+	t.Run("given a slow API when cloning with a shorter timeout then only the clone times out", func(t *testing.T) {
+		// GIVEN
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+		server.SetLatency(200 * time.Millisecond)
+		resolver := server.Resolver()
+
+		// WHEN
+		clone := resolver.Clone(pokemon.WithClientTimeout(20 * time.Millisecond))
+
+		// THEN
+		_, err := clone.Pokemon("pikachu").Get()
+		require.Error(t, err)
+
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+	})
+
+	t.Run("given a custom http client when the caller changes it then clones are not affected", func(t *testing.T) {
+		// GIVEN
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+		server.SetLatency(100 * time.Millisecond)
+
+		httpClient := &http.Client{Timeout: 5 * time.Second}
+		resolver := server.Resolver(pokemon.Config{HTTPClient: httpClient})
+
+		// WHEN the caller changes its client after creating the resolver
+		httpClient.Timeout = 10 * time.Millisecond
+		clone := resolver.Clone(pokemon.WithBatchWorkers(2))
+
+		// THEN the clone keeps the timeout the resolver was created with
+		_, err := clone.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+	})
+
+	t.Run("given a cached resolver when cloning it with the cache disabled then the clone doesn't cache", func(t *testing.T) {
+		// GIVEN
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+		resolver := server.Resolver(pokemon.Config{CacheEnabled: true, CacheTTL: time.Minute})
+
+		// WHEN
+		clone := resolver.Clone(pokemon.WithCacheEnabled(false))
+		for range 2 {
+			_, err := clone.Pokemon("pikachu").Get()
+			require.NoError(t, err)
+		}
+
+		// THEN
+		require.Equal(t, 2, server.Calls("/pokemon/pikachu"))
+	})
+
+	t.Run("given middleware when cloning with more middleware then the original is not changed", func(t *testing.T) {
+		// GIVEN
+		var headers []http.Header
+		var mu sync.Mutex
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+		record := func(next http.RoundTripper) http.RoundTripper {
+			return pokemon.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				headers = append(headers, req.Header.Clone())
+				mu.Unlock()
+				return next.RoundTrip(req)
+			})
+		}
+		resolver := server.Resolver(pokemon.Config{Middleware: []pokemon.Middleware{pokemon.SetHeader("X-Team", "red")}})
+
+		// WHEN
+		clone := resolver.Clone(pokemon.WithMiddleware(pokemon.SetHeader("X-Request", "clone"), record))
+		_, err := clone.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Clone(pokemon.WithMiddleware(record)).Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN
+		require.Len(t, headers, 2)
+		require.Equal(t, "red", headers[0].Get("X-Team"))
+		require.Equal(t, "clone", headers[0].Get("X-Request"))
+		require.Equal(t, "red", headers[1].Get("X-Team"))
+		require.Empty(t, headers[1].Get("X-Request"))
+	})
+
+	t.Run("given a shared resolver when cloning and getting concurrently then there is no race", func(t *testing.T) {
+		// GIVEN
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+		resolver := server.Resolver(pokemon.Config{CacheEnabled: true, CacheTTL: time.Minute})
+
+		// WHEN
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				clone := resolver.Clone(pokemon.WithClientTimeout(time.Duration(i+1) * time.Second))
+				_, err := clone.Pokemon("pikachu").Get()
+				require.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		// THEN every clone counted its lookup in the shared stats
+		stats, err := resolver.Cache().Stats(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(8), stats.Hits+stats.Misses)
+	})
+}
@@ -0,0 +1,82 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"github.com/boyski33/pokemon-sdk/v2/pokemontest"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPokemonTestServer(t *testing.T) {
+	t.Run("given seeded server when getting resources then serve them and count the calls", func(t *testing.T) {
+		// GIVEN a fake API seeded from a struct and from a JSON fixture
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 1, Name: "bulbasaur"})
+		require.NoError(t, server.AddJSONFile("pokemon", "testdata/pikachu-stub.json"))
+		require.NoError(t, server.AddJSON("generation", genSevenStub))
+
+		resolver := server.Resolver(pokemon.Config{CacheEnabled: true})
+
+		p, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		require.Equal(t, 25, p.ID)
+		p, err = resolver.Pokemon("1").Get()
+		require.NoError(t, err)
+		require.Equal(t, "bulbasaur", p.Name)
+		g, err := resolver.Generation("generation-vii").Get()
+		require.NoError(t, err)
+		require.Equal(t, 7, g.ID)
+
+		_, err = resolver.Pokemon("mewtwo").Get()
+		require.ErrorIs(t, err, model.ErrNotFound)
+
+		// THEN cached resources are only requested once
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		require.Equal(t, 1, server.Calls("/pokemon/pikachu"))
+		require.Equal(t, 4, server.TotalCalls())
+
+		server.ResetCalls()
+		require.Zero(t, server.TotalCalls())
+	})
+
+	t.Run("given seeded server when listing then paginate in ID order", func(t *testing.T) {
+		server := pokemontest.NewServer(t)
+		for i, name := range pokemonNames {
+			server.AddPokemon(&model.Pokemon{ID: len(pokemonNames) - i, Name: name})
+		}
+
+		page, err := server.Resolver().PokemonList(2, 3).GetPage(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 7, page.Count)
+		require.True(t, page.HasNext())
+		require.Equal(t, []string{"charmander", "venusaur", "ivysaur"}, page.Names())
+
+		all, err := server.Resolver().AllGenerationNames(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, all)
+	})
+
+	t.Run("given error rate and latency when getting resources then fail and delay the responses", func(t *testing.T) {
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+		server.SetErrorRate(1, 503)
+
+		_, err := server.Resolver().Pokemon("pikachu").Get()
+		require.Error(t, err)
+		require.NotErrorIs(t, err, model.ErrNotFound)
+
+		server.SetErrorRate(0, 0)
+		server.SetLatency(50 * time.Millisecond)
+
+		start := time.Now()
+		_, err = server.Resolver().Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+}
@@ -0,0 +1,151 @@
+//go:build redis
+
+package test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// These tests run against a real Redis server, at POKEMON_SDK_REDIS_ADDR or localhost:6379. The server should not be
+// used by anything else while they run, since they count its connections and pause it.
+
+func redisAddr() string {
+	if addr := os.Getenv("POKEMON_SDK_REDIS_ADDR"); addr != "" {
+		return addr
+	}
+
+	return "localhost:6379"
+}
+
+// newRealRedisCache returns a RedisCache with a key prefix of its own, which is flushed when the test finishes, and
+// the prefix.
+func newRealRedisCache(t *testing.T, opts pokemon.RedisCacheOptions) (*pokemon.RedisCache, string) {
+	opts.Addr = redisAddr()
+	opts.KeyPrefix = fmt.Sprintf("pokemon-sdk-test:%d:", time.Now().UnixNano())
+
+	cache := pokemon.NewRedisCache(opts)
+	t.Cleanup(func() { _ = cache.Flush(context.Background()) })
+
+	return cache, opts.KeyPrefix
+}
+
+// rawRedis sends a single command on a new connection and returns the reply, without going through RedisCache.
+func rawRedis(t *testing.T, args ...string) string {
+	conn, err := net.DialTimeout("tcp", redisAddr(), time.Second)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	command := fmt.Sprintf("*%d\r\n", len(args))
+	for _, arg := range args {
+		command += fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err = io.WriteString(conn, command)
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	line = strings.TrimSuffix(line, "\r\n")
+
+	if !strings.HasPrefix(line, "$") {
+		return line
+	}
+
+	size, err := strconv.Atoi(line[1:])
+	require.NoError(t, err)
+	data := make([]byte, size+2)
+	_, err = io.ReadFull(reader, data)
+	require.NoError(t, err)
+
+	return string(data[:size])
+}
+
+// redisClients returns the number of clients connected to the server, including the one asking.
+func redisClients(t *testing.T) int {
+	return len(strings.Split(strings.TrimSpace(rawRedis(t, "CLIENT", "LIST")), "\n"))
+}
+
+func TestRedisCache_Real(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("given a key of another type when getting it then return the error reply and keep the connection", func(t *testing.T) {
+		// GIVEN
+		cache, prefix := newRealRedisCache(t, pokemon.RedisCacheOptions{PoolSize: 1})
+		require.NoError(t, cache.Set(ctx, "pikachu", []byte("pika"), time.Minute))
+		require.Equal(t, ":1", rawRedis(t, "RPUSH", prefix+"list", "charmander"))
+
+		// WHEN
+		_, err := cache.Get(ctx, "list")
+
+		// THEN the error of the server is returned without marking it down
+		require.ErrorContains(t, err, "WRONGTYPE")
+		require.NotContains(t, err.Error(), "unavailable")
+
+		// THEN the connection reads the next reply in sync
+		data, err := cache.Get(ctx, "pikachu")
+		require.NoError(t, err)
+		require.Equal(t, []byte("pika"), data)
+	})
+
+	t.Run("given more concurrent commands than the pool size then only keep the pool size of connections", func(t *testing.T) {
+		// GIVEN
+		before := redisClients(t)
+		cache, _ := newRealRedisCache(t, pokemon.RedisCacheOptions{PoolSize: 2})
+
+		// WHEN
+		var wg sync.WaitGroup
+		for i := range 64 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key := strconv.Itoa(i)
+				require.NoError(t, cache.Set(ctx, key, []byte(key), time.Minute))
+				data, err := cache.Get(ctx, key)
+				require.NoError(t, err)
+				require.Equal(t, []byte(key), data)
+			}()
+		}
+		wg.Wait()
+
+		// THEN
+		require.Eventually(t, func() bool { return redisClients(t) <= before+2 }, 5*time.Second, 50*time.Millisecond)
+		keys, err := cache.Keys(ctx)
+		require.NoError(t, err)
+		require.Len(t, keys, 64)
+	})
+
+	t.Run("given a paused server when the caller cancels then return early and keep using redis", func(t *testing.T) {
+		// GIVEN
+		cache, _ := newRealRedisCache(t, pokemon.RedisCacheOptions{Timeout: 10 * time.Second})
+		require.NoError(t, cache.Set(ctx, "pikachu", []byte("pika"), time.Minute))
+		require.NoError(t, cache.Set(ctx, "eevee", []byte("eevee"), time.Minute))
+		require.Equal(t, "+OK", rawRedis(t, "CLIENT", "PAUSE", "500", "ALL"))
+
+		// WHEN the caller cancels while the server doesn't reply
+		cancelCtx, cancel := context.WithCancel(ctx)
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := cache.Get(cancelCtx, "pikachu")
+
+		// THEN
+		require.ErrorIs(t, err, context.Canceled)
+		require.Less(t, time.Since(start), 400*time.Millisecond)
+
+		// THEN once the server resumes, the reply of the cancelled command isn't mistaken for the next one
+		data, err := cache.Get(ctx, "eevee")
+		require.NoError(t, err)
+		require.Equal(t, []byte("eevee"), data)
+	})
+}
@@ -0,0 +1,415 @@
+//go:build integration
+
+package test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis is a local stand-in for a Redis server, speaking enough of the protocol for pokemon.RedisCache.
+type fakeRedis struct {
+	listener net.Listener
+
+	mu      sync.Mutex
+	entries map[string]fakeRedisEntry
+	// keys holding a value of another type than a string, which GET fails for
+	wrongType map[string]bool
+	// the number of open client connections
+	conns int
+}
+
+type fakeRedisEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	r := &fakeRedis{listener: listener, entries: map[string]fakeRedisEntry{}, wrongType: map[string]bool{}}
+	go r.serve()
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func (r *fakeRedis) Addr() string {
+	return r.listener.Addr().String()
+}
+
+func (r *fakeRedis) Close() {
+	_ = r.listener.Close()
+}
+
+func (r *fakeRedis) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var keys []string
+	for key := range r.entries {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// SetWrongType makes the key hold a value of another type, like a list, so GET fails with an error reply.
+func (r *fakeRedis) SetWrongType(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.wrongType[key] = true
+}
+
+// Conns returns the number of open client connections.
+func (r *fakeRedis) Conns() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.conns
+}
+
+func (r *fakeRedis) serve() {
+	for {
+		conn, err := r.listener.Accept()
+		if err != nil {
+			return
+		}
+		go r.handle(conn)
+	}
+}
+
+func (r *fakeRedis) handle(conn net.Conn) {
+	r.mu.Lock()
+	r.conns++
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.conns--
+		r.mu.Unlock()
+	}()
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			return
+		}
+		_, _ = io.WriteString(conn, r.exec(args))
+	}
+}
+
+func (r *fakeRedis) exec(args []string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "AUTH", "SELECT", "PING":
+		return "+OK\r\n"
+	case "GET":
+		if r.wrongType[args[1]] {
+			return "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"
+		}
+		entry, ok := r.entries[args[1]]
+		if !ok || (!entry.expires.IsZero() && time.Now().After(entry.expires)) {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(entry.value), entry.value)
+	case "SET":
+		entry := fakeRedisEntry{value: []byte(args[2])}
+		if len(args) == 5 && strings.ToUpper(args[3]) == "PX" {
+			ms, _ := strconv.Atoi(args[4])
+			entry.expires = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		}
+		r.entries[args[1]] = entry
+		return "+OK\r\n"
+	case "DEL":
+		var deleted int
+		for _, key := range args[1:] {
+			if _, ok := r.entries[key]; ok {
+				delete(r.entries, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	case "SCAN":
+		// everything is returned in a single page
+		prefix := strings.TrimSuffix(args[3], "*")
+		prefix = strings.NewReplacer(`\\`, `\`, `\*`, `*`, `\?`, `?`, `\[`, `[`, `\]`, `]`).Replace(prefix)
+
+		var keys []string
+		for key := range r.entries {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, fmt.Sprintf("$%d\r\n%s\r\n", len(key), key))
+			}
+		}
+		return fmt.Sprintf("*2\r\n$1\r\n0\r\n*%d\r\n%s", len(keys), strings.Join(keys, ""))
+	default:
+		return "-ERR unknown command\r\n"
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, n)
+	for i := range args {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+
+	return args, nil
+}
+
+func TestRedisCache(t *testing.T) {
+	t.Run("given two resolvers sharing redis when getting the same pokemon then only call server once", func(t *testing.T) {
+		var mockServerInvocations int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations++
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN two resolvers, as if running in two replicas, sharing a Redis cache
+		redis := newFakeRedis(t)
+		newReplica := func() *pokemon.Resolver {
+			return pokemon.NewResolver().WithConfig(pokemon.Config{
+				BaseURL: mockServer.URL,
+				Cache: pokemon.NewRedisCache(pokemon.RedisCacheOptions{
+					Addr:      redis.Addr(),
+					KeyPrefix: "replicas:",
+				}),
+				CacheTTL: time.Minute,
+			})
+		}
+
+		// WHEN each replica gets the same Pokemon
+		_, err := newReplica().Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = newReplica().Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN server only called once
+		require.Equal(t, 1, mockServerInvocations)
+
+		// THEN the keys are prefixed
+		expectedKeys := []string{mockServer.URL + "/pokemon/pikachu", mockServer.URL + "/pokemon/25"}
+		require.ElementsMatch(t, []string{"replicas:" + expectedKeys[0], "replicas:" + expectedKeys[1]}, redis.Keys())
+
+		// THEN the cache can be inspected and flushed without the prefix
+		keys, err := newReplica().Cache().Keys(context.Background())
+		require.NoError(t, err)
+		require.ElementsMatch(t, expectedKeys, keys)
+		require.NoError(t, newReplica().Cache().Flush(context.Background()))
+		require.Empty(t, redis.Keys())
+	})
+
+	t.Run("given redis is down when getting pokemon then fall back to uncached fetches", func(t *testing.T) {
+		var mockServerInvocations int
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations++
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN a Redis server which is down
+		redis := newFakeRedis(t)
+		redis.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL: mockServer.URL,
+			Cache:   pokemon.NewRedisCache(pokemon.RedisCacheOptions{Addr: redis.Addr()}),
+		})
+
+		// WHEN calling twice
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN both calls succeed by calling the server
+		require.Equal(t, 2, mockServerInvocations)
+	})
+
+	t.Run("given a cancelled caller when getting from redis then don't mark redis down", func(t *testing.T) {
+		// GIVEN
+		redis := newFakeRedis(t)
+		cache := pokemon.NewRedisCache(pokemon.RedisCacheOptions{Addr: redis.Addr()})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// WHEN getting with the cancelled context
+		_, err := cache.Get(ctx, "pikachu")
+
+		// THEN the error is the cancellation
+		require.ErrorIs(t, err, context.Canceled)
+
+		// THEN the next caller still reaches redis
+		_, err = cache.Get(context.Background(), "pikachu")
+		require.ErrorIs(t, err, pokemon.ErrCacheMiss)
+	})
+
+	t.Run("given a hanging redis when the caller cancels then return before the timeout", func(t *testing.T) {
+		// GIVEN a server which accepts connections but never replies
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+			}
+		}()
+
+		cache := pokemon.NewRedisCache(pokemon.RedisCacheOptions{Addr: listener.Addr().String(), Timeout: 10 * time.Second})
+
+		// WHEN the caller cancels while waiting for the reply
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		_, err = cache.Get(ctx, "pikachu")
+
+		// THEN
+		require.ErrorIs(t, err, context.Canceled)
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("given an error reply when getting from redis then keep using redis and the connection", func(t *testing.T) {
+		// GIVEN
+		redis := newFakeRedis(t)
+		redis.SetWrongType("pokemon-sdk:list")
+		cache := pokemon.NewRedisCache(pokemon.RedisCacheOptions{Addr: redis.Addr(), PoolSize: 1})
+		ctx := context.Background()
+		require.NoError(t, cache.Set(ctx, "pikachu", []byte("pika"), 0))
+
+		// WHEN getting a key the server fails the command for
+		_, err := cache.Get(ctx, "list")
+
+		// THEN the error of the server is returned
+		require.ErrorContains(t, err, "WRONGTYPE")
+		require.NotContains(t, err.Error(), "unavailable")
+
+		// THEN the connection is reused and reads the next reply in sync
+		data, err := cache.Get(ctx, "pikachu")
+		require.NoError(t, err)
+		require.Equal(t, []byte("pika"), data)
+		require.Equal(t, 1, redis.Conns())
+	})
+
+	t.Run("given more concurrent commands than the pool size then dial extra connections and close them after", func(t *testing.T) {
+		// GIVEN
+		redis := newFakeRedis(t)
+		cache := pokemon.NewRedisCache(pokemon.RedisCacheOptions{Addr: redis.Addr(), PoolSize: 2})
+		ctx := context.Background()
+
+		// WHEN
+		var wg sync.WaitGroup
+		for i := range 32 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key := strconv.Itoa(i)
+				require.NoError(t, cache.Set(ctx, key, []byte(key), 0))
+				data, err := cache.Get(ctx, key)
+				require.NoError(t, err)
+				require.Equal(t, []byte(key), data)
+			}()
+		}
+		wg.Wait()
+
+		// THEN only the pool size of connections is kept open
+		require.Eventually(t, func() bool { return redis.Conns() <= 2 }, time.Second, 5*time.Millisecond)
+		keys, err := cache.Keys(ctx)
+		require.NoError(t, err)
+		require.Len(t, keys, 32)
+	})
+
+	t.Run("given a reply cut off when the caller cancels then discard the connection", func(t *testing.T) {
+		// GIVEN a server which sends half of the first reply and stalls, and answers everything else with a miss
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		var first sync.Once
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					reader := bufio.NewReader(conn)
+					for {
+						if _, err := readCommand(reader); err != nil {
+							return
+						}
+						stall := false
+						first.Do(func() { stall = true })
+						if stall {
+							_, _ = io.WriteString(conn, "$4\r\npi")
+							time.Sleep(10 * time.Second)
+							return
+						}
+						_, _ = io.WriteString(conn, "$-1\r\n")
+					}
+				}()
+			}
+		}()
+
+		cache := pokemon.NewRedisCache(pokemon.RedisCacheOptions{Addr: listener.Addr().String(), Timeout: 10 * time.Second})
+
+		// WHEN the caller cancels while the reply is being read
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		_, err = cache.Get(ctx, "pikachu")
+		require.ErrorIs(t, err, context.Canceled)
+
+		// THEN the next command uses a new connection instead of reading the rest of the cut off reply
+		_, err = cache.Get(context.Background(), "pikachu")
+		require.ErrorIs(t, err, pokemon.ErrCacheMiss)
+	})
+}
@@ -0,0 +1,156 @@
+//go:build integration
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"github.com/boyski33/pokemon-sdk/v2/pokemontest"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	// GIVEN a snapshot exported from a resolver which fetched a Pokemon, a Generation and a list page
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/pokemon/"):
+			_, _ = w.Write(pikachuStub)
+		case strings.HasPrefix(r.URL.Path, "/generation/"):
+			_, _ = w.Write(genSevenStub)
+		default:
+			writeNamedResourceList(w, r, "pokemon", pokemonNames)
+		}
+	}))
+	defer mockServer.Close()
+
+	online := pokemon.NewResolver().WithConfig(pokemon.Config{
+		BaseURL:      mockServer.URL,
+		CacheEnabled: true,
+	})
+	_, err := online.Pokemon("pikachu").Get()
+	require.NoError(t, err)
+	_, err = online.Generation("generation-vii").Get()
+	require.NoError(t, err)
+	_, err = online.PokemonList(1, 3).Get()
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	exported, err := online.Cache().Export(ctx, &archive)
+	require.NoError(t, err)
+	// the Pokemon and the Generation are cached under their name and ID
+	require.Equal(t, 5, exported)
+
+	snapshot, err := pokemon.LoadSnapshot(bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, exported, snapshot.Len())
+
+	t.Run("given snapshot resolver when getting exported resources then serve them without requests", func(t *testing.T) {
+		// GIVEN the API is gone
+		mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{Snapshot: snapshot})
+
+		byName, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		byID, err := resolver.Pokemon("25").Get()
+		require.NoError(t, err)
+		require.Equal(t, byName, byID)
+
+		generation, err := resolver.Generation("7").Get()
+		require.NoError(t, err)
+		require.Equal(t, "generation-vii", generation.Name)
+
+		names, err := resolver.PokemonList(1, 3).Get()
+		require.NoError(t, err)
+		require.Equal(t, pokemonNames[:3], names)
+	})
+
+	t.Run("given snapshot resolver when getting missing resources then return not found", func(t *testing.T) {
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{Snapshot: snapshot})
+
+		_, err := resolver.Pokemon("bulbasaur").Get()
+		require.ErrorIs(t, err, model.ErrNotFound)
+
+		_, err = resolver.PokemonList(2, 3).Get()
+		require.ErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("given snapshot when importing it into a cache then serve the responses from the cache", func(t *testing.T) {
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:  "http://localhost:1",
+			CacheDir: t.TempDir(),
+		})
+
+		imported, err := resolver.Cache().Import(ctx, snapshot)
+		require.NoError(t, err)
+		require.Equal(t, exported, imported)
+
+		p, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		require.Equal(t, "pikachu", p.Name)
+	})
+
+	t.Run("given redis is down when importing a snapshot then report the failure", func(t *testing.T) {
+		redis := newFakeRedis(t)
+		redis.Close()
+
+		resolver := pokemon.NewResolver(
+			pokemon.WithBaseURL("http://localhost:1"),
+			pokemon.WithCache(pokemon.NewRedisCache(pokemon.RedisCacheOptions{Addr: redis.Addr()})),
+		)
+
+		imported, err := resolver.Cache().Import(ctx, snapshot)
+		require.ErrorContains(t, err, "redis unavailable")
+		require.Zero(t, imported)
+	})
+
+	t.Run("given expired entries kept to be served stale when exporting then leave them out", func(t *testing.T) {
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+		resolver := server.Resolver(pokemon.Config{
+			CacheEnabled: true,
+			CacheTTL:     10 * time.Millisecond,
+			StaleIfError: time.Hour,
+		})
+
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		var archive bytes.Buffer
+		exported, err := resolver.Cache().Export(ctx, &archive)
+		require.NoError(t, err)
+		require.Zero(t, exported)
+	})
+
+	t.Run("given a failing writer when exporting then return the error", func(t *testing.T) {
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu"})
+		resolver := server.Resolver(pokemon.Config{CacheEnabled: true})
+
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		_, err = resolver.Cache().Export(ctx, failingWriter{})
+		require.ErrorIs(t, err, errWriteFailed)
+	})
+}
+
+var errWriteFailed = errors.New("write failed")
+
+// failingWriter fails every write, like a full disk.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWriteFailed
+}
@@ -0,0 +1,153 @@
+//go:build integration
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"github.com/boyski33/pokemon-sdk/v2/pokemontest"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResolver_Stale(t *testing.T) {
+	t.Run("given stale while revalidate when the entry expires then serve it and refresh it in the background", func(t *testing.T) {
+		var mockServerInvocations atomic.Int32
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mockServerInvocations.Add(1)
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:              mockServer.URL,
+			CacheEnabled:         true,
+			CacheTTL:             10 * time.Millisecond,
+			StaleWhileRevalidate: time.Hour,
+		})
+
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		// WHEN getting the expired Pokemon
+		stale, err := resolver.Pokemon("pikachu").Get()
+
+		// THEN the stale Pokemon is served and refreshed in the background
+		require.NoError(t, err)
+		require.Equal(t, "pikachu", stale.Name)
+		require.Eventually(t, func() bool { return mockServerInvocations.Load() == 2 }, time.Second, 5*time.Millisecond)
+
+		// THEN the refreshed Pokemon is fresh again
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		require.Equal(t, int32(2), mockServerInvocations.Load())
+	})
+
+	t.Run("given stale while revalidate when the entry is refreshed in the background then refresh its aliases", func(t *testing.T) {
+		server := pokemontest.NewServer(t)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu", Weight: 60})
+
+		cache := pokemon.NewCache(0)
+		resolver := server.Resolver(pokemon.Config{
+			Cache:                cache,
+			CacheTTL:             50 * time.Millisecond,
+			StaleWhileRevalidate: time.Hour,
+		})
+
+		// GIVEN a Pokemon cached under its name and its ID, which changed after it expired
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+		server.AddPokemon(&model.Pokemon{ID: 25, Name: "pikachu", Weight: 61})
+
+		// WHEN getting the expired Pokemon by name
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+
+		// THEN the refresh also updates the entry of the ID
+		require.Eventually(t, func() bool {
+			data, err := cache.Get(context.Background(), server.URL+"/pokemon/25")
+			return err == nil && bytes.Contains(data, []byte(`"weight":61`))
+		}, time.Second, 5*time.Millisecond)
+
+		p, err := resolver.Pokemon("25").Get()
+		require.NoError(t, err)
+		require.Equal(t, 61, p.Weight)
+		require.Zero(t, server.Calls("/pokemon/25"))
+	})
+
+	t.Run("given stale if error when the api fails then serve the expired entry", func(t *testing.T) {
+		var failing atomic.Bool
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+			CacheTTL:     10 * time.Millisecond,
+			StaleIfError: time.Hour,
+		})
+
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		// WHEN the API is down
+		failing.Store(true)
+		stale, err := resolver.Pokemon("pikachu").Get()
+
+		// THEN the expired Pokemon is served
+		require.NoError(t, err)
+		require.Equal(t, "pikachu", stale.Name)
+
+		// THEN Pokemon which were never cached still fail
+		_, err = resolver.Pokemon("bulbasaur").Get()
+		require.Error(t, err)
+		require.NotErrorIs(t, err, model.ErrNotFound)
+	})
+
+	t.Run("given no stale settings when the entry expires then fetch it again", func(t *testing.T) {
+		var failing atomic.Bool
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if failing.Load() {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+			CacheTTL:     10 * time.Millisecond,
+		})
+
+		_, err := resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		failing.Store(true)
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+//go:build integration
+
+package test
+
+import (
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolver_Tracing(t *testing.T) {
+	t.Run("given tracer provider when getting a pokemon then record spans for every step", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/pokemon/pikachu" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		// GIVEN a tracer provider recording spans in memory
+		exporter := tracetest.NewInMemoryExporter()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:        mockServer.URL,
+			CacheEnabled:   true,
+			TracerProvider: provider,
+		})
+
+		// WHEN getting a Pokemon twice
+		_, err := resolver.Pokemon("Pikachu").Get()
+		require.NoError(t, err)
+		spans := exporter.GetSpans().Snapshots()
+		exporter.Reset()
+		_, err = resolver.Pokemon("pikachu").Get()
+		require.NoError(t, err)
+		cachedSpans := exporter.GetSpans().Snapshots()
+
+		// THEN the first call has child spans for the cache lookup, the request and decoding
+		byName := map[string]sdktrace.ReadOnlySpan{}
+		for _, span := range spans {
+			byName[span.Name()] = span
+		}
+		require.Len(t, byName, 4)
+		root := byName["pokemon.get"]
+		require.NotNil(t, root)
+		for _, name := range []string{"cache.lookup", "http.fetch", "decode"} {
+			require.Contains(t, byName, name)
+			require.Equal(t, root.SpanContext().SpanID(), byName[name].Parent().SpanID(), name)
+		}
+		require.Contains(t, root.Attributes(), attribute.String("pokemon.resource.type", "pokemon"))
+		require.Contains(t, root.Attributes(), attribute.String("pokemon.resource.id", "pikachu"))
+		require.Contains(t, root.Attributes(), attribute.String("pokemon.cache.outcome", "miss"))
+		require.Contains(t, byName["http.fetch"].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+
+		// THEN the second call is a cache hit without a request
+		var names []string
+		for _, span := range cachedSpans {
+			names = append(names, span.Name())
+		}
+		require.ElementsMatch(t, []string{"pokemon.get", "cache.lookup", "decode"}, names)
+		for _, span := range cachedSpans {
+			if span.Name() == "pokemon.get" {
+				require.Contains(t, span.Attributes(), attribute.String("pokemon.cache.outcome", "hit"))
+			}
+		}
+	})
+
+	t.Run("given tracer provider when the request fails then record the error", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer mockServer.Close()
+
+		exporter := tracetest.NewInMemoryExporter()
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:        mockServer.URL,
+			TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		})
+
+		_, err := resolver.PokemonList(1, 3).Next(t.Context())
+		require.Error(t, err)
+
+		for _, span := range exporter.GetSpans().Snapshots() {
+			require.Equal(t, codes.Error, span.Status().Code, span.Name())
+			if span.Name() == "pokemon.list" {
+				require.Contains(t, span.Attributes(), attribute.String("pokemon.cache.outcome", "disabled"))
+			}
+		}
+	})
+}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package test
+
+import (
+	"context"
+	"github.com/boyski33/pokemon-sdk/v2"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestResolver_Warm(t *testing.T) {
+	t.Run("given generation when warming its pokemon then cache every species and report progress", func(t *testing.T) {
+		var (
+			mu       sync.Mutex
+			requests = map[string]int{}
+		)
+		// GIVEN a mock server with generation VII and its Pokemon
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			requests[r.URL.Path]++
+			mu.Unlock()
+
+			w.WriteHeader(http.StatusOK)
+			if strings.HasPrefix(r.URL.Path, "/generation/") {
+				_, _ = w.Write(genSevenStub)
+				return
+			}
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+		})
+
+		// WHEN warming the Pokemon of generation VII
+		var progress []pokemon.WarmProgress
+		err := resolver.Warm(context.Background(), pokemon.WarmSpec{
+			PokemonOfGenerations: []string{"generation-vii"},
+			Workers:              4,
+			Progress:             func(p pokemon.WarmProgress) { progress = append(progress, p) },
+		})
+
+		// THEN the generation and each of its 88 species is fetched once, by the ID of the species
+		require.NoError(t, err)
+		require.Equal(t, 1, requests["/generation/generation-vii"])
+		require.Equal(t, 1, requests["/pokemon/722"])
+		require.Len(t, requests, 89)
+
+		// THEN progress is reported for every resource
+		require.Len(t, progress, 89)
+		last := progress[len(progress)-1]
+		require.Equal(t, 89, last.Total)
+		require.Equal(t, 89, last.Done)
+		require.Zero(t, last.Failed)
+
+		// THEN warming again is served from the cache
+		err = resolver.Warm(context.Background(), pokemon.WarmSpec{PokemonOfGenerations: []string{"generation-vii"}})
+		require.NoError(t, err)
+		require.Equal(t, 1, requests["/generation/generation-vii"])
+	})
+
+	t.Run("given missing pokemon when warming then warm the others and report the failures", func(t *testing.T) {
+		mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/pokemon/missingno" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(pikachuStub)
+		}))
+		defer mockServer.Close()
+
+		resolver := pokemon.NewResolver().WithConfig(pokemon.Config{
+			BaseURL:      mockServer.URL,
+			CacheEnabled: true,
+		})
+
+		var last pokemon.WarmProgress
+		err := resolver.Warm(context.Background(), pokemon.WarmSpec{
+			Pokemon:  []string{"pikachu", "missingno", "Pikachu"},
+			Progress: func(p pokemon.WarmProgress) { last = p },
+		})
+
+		var batchErr *pokemon.BatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.Equal(t, "pokemon/missingno", batchErr.ID)
+		require.Equal(t, 2, last.Total)
+		require.Equal(t, 1, last.Failed)
+
+		stats, err := resolver.Cache().Stats(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, stats.Items)
+	})
+}
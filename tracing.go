@@ -0,0 +1,37 @@
+package pokemon
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation scope of the spans of the SDK.
+const tracerName = "github.com/boyski33/pokemon-sdk/v2"
+
+// The attributes of the spans of the SDK, in addition to the ones of the OpenTelemetry semantic conventions.
+const (
+	attrResourceType = attribute.Key("pokemon.resource.type")
+	attrResourceID   = attribute.Key("pokemon.resource.id")
+	attrCacheOutcome = attribute.Key("pokemon.cache.outcome")
+	attrURL          = attribute.Key("url.full")
+	attrStatusCode   = attribute.Key("http.response.status_code")
+	attrBodySize     = attribute.Key("http.response.body.size")
+)
+
+// The outcomes of a cache lookup.
+const (
+	cacheOutcomeHit      = "hit"
+	cacheOutcomeStale    = "stale"
+	cacheOutcomeMiss     = "miss"
+	cacheOutcomeDisabled = "disabled"
+)
+
+// endSpan records the error, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
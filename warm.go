@@ -0,0 +1,168 @@
+package pokemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/boyski33/pokemon-sdk/v2/model"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// WarmSpec describes the resources Resolver.Warm puts in the cache.
+type WarmSpec struct {
+	// The Pokemon to warm, by ID or name
+	Pokemon []string
+	// The Generations to warm, by ID or name
+	Generations []string
+	// The Generations whose Pokemon species are warmed, along with the Generations themselves
+	PokemonOfGenerations []string
+	// If you want every Pokemon warmed, along with the Pokemon index
+	AllPokemon bool
+	// If you want every Generation warmed, along with the Generation index
+	AllGenerations bool
+	// The maximum number of concurrent requests, Config.BatchWorkers by default
+	Workers int
+	// Called after every resource is warmed, one call at a time
+	Progress func(WarmProgress)
+}
+
+// WarmProgress reports how far Resolver.Warm got.
+type WarmProgress struct {
+	// The number of resources to warm
+	Total int
+	// The number of resources warmed so far, including the failed ones
+	Done int
+	// The number of resources which failed to warm so far
+	Failed int
+	// The resource which was just warmed e.g., "pokemon/pikachu"
+	Resource string
+	// The error returned when warming the resource
+	Err error
+}
+
+// Warm puts the resources described by the spec in the cache, so later requests for them are served without waiting
+// for the API. It first finds out which resources to fetch, e.g. the Pokemon species of the requested Generations, and
+// then fetches them concurrently. Resources which are already cached are not fetched again.
+//
+// If some resources fail, the others are still warmed and the returned error combines a BatchError for each of them.
+// Warm does nothing if the cache is disabled.
+func (r *Resolver) Warm(ctx context.Context, spec WarmSpec) error {
+	if r.client.cache == nil {
+		return nil
+	}
+
+	tasks, discoveryErr := r.warmTasks(ctx, spec)
+
+	workers := spec.Workers
+	if workers <= 0 {
+		workers = r.batchWorkers
+	}
+
+	var (
+		mu       sync.Mutex
+		progress = WarmProgress{Total: len(tasks)}
+	)
+	_, err := fetchBatch(ctx, tasks, workers, func(ctx context.Context, task string) (struct{}, error) {
+		err := r.warmOne(ctx, task)
+
+		mu.Lock()
+		defer mu.Unlock()
+		progress.Done++
+		if err != nil {
+			progress.Failed++
+		}
+		progress.Resource = task
+		progress.Err = err
+		if spec.Progress != nil {
+			spec.Progress(progress)
+		}
+
+		return struct{}{}, err
+	})
+
+	return errors.Join(discoveryErr, err)
+}
+
+// warmTasks returns every resource described by the spec as "resource/id", without duplicates.
+func (r *Resolver) warmTasks(ctx context.Context, spec WarmSpec) ([]string, error) {
+	var (
+		tasks []string
+		seen  = map[string]bool{}
+		errs  []error
+	)
+	add := func(resource, idOrName string) {
+		id := normalizeIdentifier(idOrName)
+		if task := resource + "/" + id; id != "" && !seen[task] {
+			seen[task] = true
+			tasks = append(tasks, task)
+		}
+	}
+
+	if spec.AllGenerations {
+		all, err := r.client.GetAllGenerationNames(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, res := range all {
+			add("generation", res.Name)
+		}
+	}
+
+	for _, id := range spec.Generations {
+		add("generation", id)
+	}
+
+	for _, id := range spec.PokemonOfGenerations {
+		add("generation", id)
+
+		generation, err := r.client.GetGenerationByIDOrName(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to find the Pokemon of generation %s: %w", id, err))
+			continue
+		}
+		for _, species := range generation.PokemonSpecies {
+			add("pokemon", speciesPokemonID(species))
+		}
+	}
+
+	if spec.AllPokemon {
+		all, err := r.client.GetAllPokemonNames(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, res := range all {
+			add("pokemon", res.Name)
+		}
+	}
+
+	for _, id := range spec.Pokemon {
+		add("pokemon", id)
+	}
+
+	return tasks, errors.Join(errs...)
+}
+
+// speciesPokemonID returns the identifier of the default Pokemon of a species. It shares the ID of the species, while
+// the names may differ e.g., the species "deoxys" and the Pokemon "deoxys-normal".
+func speciesPokemonID(species model.NamedResource) string {
+	id := path.Base(strings.TrimSuffix(species.URL, "/"))
+	if _, err := strconv.Atoi(id); err == nil {
+		return id
+	}
+	return species.Name
+}
+
+// warmOne fetches a single resource returned by warmTasks.
+func (r *Resolver) warmOne(ctx context.Context, task string) error {
+	resource, idOrName, _ := strings.Cut(task, "/")
+	if resource == "generation" {
+		_, err := r.client.GetGenerationByIDOrName(ctx, idOrName)
+		return err
+	}
+
+	_, err := r.client.GetPokemonByIDOrName(ctx, idOrName)
+	return err
+}